@@ -2,46 +2,178 @@ package adapters
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/victorfernandesraton/lazydin/domain"
 )
 
+// keys of selectors used to parse posts, see selectors/selectors.toml
 const (
-	author_name        = "li div.update-components-actor div .update-components-actor__title span span span"
-	author_description = "li div.update-components-actor div .update-components-actor__description"
-	autor_avatar       = "li div.update-components-actor div  a.app-aware-link"
-	post               = "li div.update-components-text span.break-words"
-	post_link          = "li div.feed-shared-update-v2"
+	author_name        = "content.author_name"
+	author_description = "content.author_description"
+	autor_avatar       = "content.author_avatar"
+	author_image       = "content.author_image"
+	author_degree      = "content.author_degree"
+	author_followers   = "content.author_followers"
+	author_verified    = "content.author_verified"
+	author_premium     = "content.author_premium"
+	post               = "content.post"
+	post_link          = "content.post_link"
+	reactions          = "content.reactions"
+	comments           = "content.comments"
+	reposts            = "content.reposts"
+	relative_time      = "content.relative_time"
+	hashtags           = "content.hashtags"
+	mentions           = "content.mentions"
 )
 
+// ErrUnsupportedPost is returned when post urn is not an activity, ugcPost or share, like sponsored updates
+var ErrUnsupportedPost = errors.New("unsupported post")
+
+var (
+	// degreeRegex match connection degree badges like 1st, 2nd, 3rd+ or 2º
+	degreeRegex = regexp.MustCompile(`\b([123])(?:st|nd|rd|º|°)\+?`)
+	// followersRegex check if a text is a followers counter, like 12,345 followers or 1.2K seguidores
+	followersRegex = regexp.MustCompile(`(?i)followers|seguidores`)
+)
+
+// countRegex match counters like 418, 1,234, 1.2K or portuguese 1,2 mil and 3 mi
+var countRegex = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)*)\s*(?:(mil|mi|[km])\b)?`)
+
 func ExtractAuthor(dom *goquery.Document) (*domain.Author, error) {
-	url, hasUrl := dom.Find(autor_avatar).Attr("href")
+	url, hasUrl := find(dom, autor_avatar).Attr("href")
 	if !hasUrl {
 		return nil, nil
 	}
 	author := &domain.Author{
-		Name:        dom.Find(author_name).First().Text(),
-		Description: dom.Find(author_description).First().Text(),
+		Name:        find(dom, author_name).First().Text(),
+		Description: find(dom, author_description).First().Text(),
 		Url:         url,
+		AvatarUrl:   find(dom, author_image).First().AttrOr("src", ""),
+		Degree:      parseDegree(find(dom, author_degree).First().Text()),
+		Followers:   extractFollowers(dom),
+		Premium:     find(dom, author_premium).Length() > 0,
+		Verified:    find(dom, author_verified).Length() > 0,
 	}
 	return author, nil
 }
 
+// parseDegree convert connection badges like "• 2nd" to 2, zero for others like "• Following"
+func parseDegree(text string) int {
+	match := degreeRegex.FindStringSubmatch(text)
+	if match == nil {
+		return 0
+	}
+	degree, _ := strconv.Atoi(match[1])
+	return degree
+}
+
+func extractFollowers(dom *goquery.Document) int {
+	text := find(dom, author_followers).First().Text()
+	if !followersRegex.MatchString(text) {
+		return 0
+	}
+	return parseCount(text)
+}
+
 func ExtractPost(dom *goquery.Document) (*domain.Post, error) {
-	urn, hasUrn := dom.Find(post_link).First().Attr("data-urn")
+	ref, hasUrn := find(dom, post_link).First().Attr("data-urn")
 	if !hasUrn {
 		return nil, errors.New("Not found urn in user")
 	}
+	urn, err := domain.ParseURN(ref)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedPost, err)
+	}
 
 	post := &domain.Post{
-		Url:     urn,
-		Content: dom.Find(post).First().Text(),
+		Urn:          urn,
+		Url:          urn.Permalink(),
+		Content:      cleanText(find(dom, post)),
+		Reactions:    extractCount(dom, reactions),
+		Comments:     extractCount(dom, comments),
+		Reposts:      extractCount(dom, reposts),
+		RelativeTime: extractRelativeTime(dom),
+		Hashtags:     extractHashtags(dom),
+		Mentions:     extractMentions(dom),
+		Media:        ExtractMedia(dom),
 	}
+	post.PostedAt, _ = domain.PostedAt(post.RelativeTime, time.Now())
 	return post, nil
 }
 
+// extractCount read a counter from aria-label, that has the full number, or from element text
+func extractCount(dom *goquery.Document, key string) int {
+	selection := find(dom, key).First()
+	return parseCount(selection.AttrOr("aria-label", selection.Text()))
+}
+
+// parseCount convert linkedin counters like 418, 1,234 or 1.2K to int, zero when there is no number
+func parseCount(text string) int {
+	match := countRegex.FindStringSubmatch(text)
+	if match == nil {
+		return 0
+	}
+	number, multiplier := match[1], 1
+	switch strings.ToLower(match[2]) {
+	case "k", "mil":
+		multiplier = 1_000
+	case "m", "mi":
+		multiplier = 1_000_000
+	}
+	if multiplier > 1 {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+		if err != nil {
+			return 0
+		}
+		return int(math.Round(value * float64(multiplier)))
+	}
+	value, err := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(number))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// extractRelativeTime returns the post age, like 4mo from "4mo • Edited •"
+func extractRelativeTime(dom *goquery.Document) string {
+	text := find(dom, relative_time).First().Text()
+	return strings.TrimSpace(strings.SplitN(text, "•", 2)[0])
+}
+
+func extractHashtags(dom *goquery.Document) (tags domain.List) {
+	find(dom, hashtags).Each(func(_ int, link *goquery.Selection) {
+		tag := strings.TrimPrefix(strings.TrimSpace(link.Text()), "#")
+		if href, err := url.Parse(link.AttrOr("href", "")); err == nil && href.Query().Get("keywords") != "" {
+			tag = href.Query().Get("keywords")
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	})
+	return tags
+}
+
+// extractMentions returns profile urls of people mentioned in post, without query string
+func extractMentions(dom *goquery.Document) (profiles domain.List) {
+	find(dom, mentions).Each(func(_ int, link *goquery.Selection) {
+		href, err := url.Parse(link.AttrOr("href", ""))
+		if err != nil || href.Path == "" {
+			return
+		}
+		href.RawQuery, href.Fragment = "", ""
+		profiles = append(profiles, href.String())
+	})
+	return profiles
+}
+
 func ExtractContent(results []string) (contents []domain.Content, err error) {
 	for _, v := range results {
 		dom, err := goquery.NewDocumentFromReader(strings.NewReader(v))
@@ -54,6 +186,9 @@ func ExtractContent(results []string) (contents []domain.Content, err error) {
 		}
 		if author != nil {
 			post, err := ExtractPost(dom)
+			if errors.Is(err, ErrUnsupportedPost) {
+				continue
+			}
 			if err != nil {
 				return nil, err
 			}
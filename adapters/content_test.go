@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/victorfernandesraton/lazydin/adapters"
@@ -43,6 +44,70 @@ func TestParseAuthor(t *testing.T) {
 	}
 
 }
+func TestParseAuthorProfile(t *testing.T) {
+	res, err := adapters.ExtractAuthor(dom)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !strings.HasPrefix(res.AvatarUrl, "https://media.licdn.com/") {
+		t.Fatalf("unexpected avatar %s", res.AvatarUrl)
+	}
+	if res.Degree != 0 || res.Followers != 0 || res.Premium || res.Verified {
+		t.Fatalf("expect followed author without degree and badges, got %+v", res)
+	}
+
+	t.Run("should parse degree, followers and badges", func(t *testing.T) {
+		html := `<li><div class="update-components-actor">
+			<a class="update-components-actor__image" href="https://www.linkedin.com/in/recruiter"><img class="update-components-actor__avatar-image" src="https://media.licdn.com/recruiter.jpg"></a>
+			<div><a class="app-aware-link" href="https://www.linkedin.com/in/recruiter">
+				<span class="update-components-actor__title">
+					<span><span><span>Recruiter</span></span></span>
+					<svg data-test-icon="verified-small"></svg>
+					<li-icon type="premium-badge"></li-icon>
+					<span class="update-components-actor__supplementary-actor-info"><span aria-hidden="true"> • 3rd+</span></span>
+				</span>
+				<span class="update-components-actor__description"><span aria-hidden="true">12,345 followers</span></span>
+			</a></div>
+		</div></li>`
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		res, err := adapters.ExtractAuthor(dom)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if res.Degree != 3 || res.Followers != 12345 || !res.Premium || !res.Verified {
+			t.Fatalf("expect 3rd degree, 12345 followers, premium and verified, got %+v", res)
+		}
+	})
+
+	t.Run("should parse localized followers counters", func(t *testing.T) {
+		cases := map[string]int{
+			"1,2 mil seguidores": 1200,
+			"12.345 seguidores":  12345,
+			"3 mi seguidores":    3000000,
+			"1.2K followers":     1200,
+		}
+		for text, expected := range cases {
+			html := `<li><div class="update-components-actor"><div><a class="app-aware-link" href="https://www.linkedin.com/in/someone">
+				<span class="update-components-actor__description"><span aria-hidden="true">` + text + `</span></span>
+			</a></div></div></li>`
+			dom, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			res, err := adapters.ExtractAuthor(dom)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if res.Followers != expected {
+				t.Fatalf("expect %d followers from %q, got %d", expected, text, res.Followers)
+			}
+		}
+	})
+}
+
 func TestParsePost(t *testing.T) {
 	res, err := adapters.ExtractPost(dom)
 
@@ -51,8 +116,126 @@ func TestParsePost(t *testing.T) {
 		t.Fail()
 	}
 
-	if res.Url == "" {
-		t.Fatalf("Not found url")
+	if res.Urn != "urn:li:activity:7151313167762010113" {
+		t.Fatalf("unexpected urn %s", res.Urn)
+	}
+	if res.Url != res.Urn.Permalink() {
+		t.Fatalf("expect permalink as url, got %s", res.Url)
+	}
+
+}
+
+func TestCheckContent(t *testing.T) {
+	html, err := dom.Html()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checks, err := adapters.CheckContent([]string{html, "<li><div>not a post</div></li>"})
+	if err != nil {
+		t.Fatalf(err.Error())
 	}
+	if len(checks) == 0 {
+		t.Fatalf("expect field checks")
+	}
+	for _, check := range checks {
+		if check.Found != 1 || check.Total != 2 || check.Ok() {
+			t.Fatalf("expect %s found in 1 of 2 results, got %d of %d", check.Field, check.Found, check.Total)
+		}
+	}
+}
 
+func TestParsePostEngagement(t *testing.T) {
+	res, err := adapters.ExtractPost(dom)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if res.Reactions != 418 || res.Comments != 45 || res.Reposts != 22 {
+		t.Fatalf("expect 418 reactions, 45 comments and 22 reposts, got %d, %d and %d", res.Reactions, res.Comments, res.Reposts)
+	}
+	if res.RelativeTime != "4mo" {
+		t.Fatalf("expect post age 4mo, got %q", res.RelativeTime)
+	}
+	if res.PostedAt.IsZero() || time.Since(res.PostedAt.Time) < 4*30*24*time.Hour {
+		t.Fatalf("expect post published 4 months ago, got %v", res.PostedAt)
+	}
+	if strings.Join(res.Hashtags, " ") != "tammyindica vagas remoto" {
+		t.Fatalf("unexpected hashtags %v", res.Hashtags)
+	}
+
+	t.Run("should parse abbreviated counters and mentions", func(t *testing.T) {
+		html := `<li><div class="feed-shared-update-v2" data-urn="urn:li:activity:1">
+			<div class="update-components-text"><span class="break-words">thanks
+				<a href="https://www.linkedin.com/in/someone?miniProfileUrn=urn">Someone</a>
+				<a href="https://www.linkedin.com/feed/hashtag/golang">#golang</a></span></div>
+			<ul>
+				<li><button aria-label="1.2K reactions"><span class="social-details-social-counts__reactions-count">1,2K</span></button></li>
+				<li><button aria-label="1,234 comments on post">1,234 comments</button></li>
+			</ul>
+		</div></li>`
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		res, err := adapters.ExtractPost(dom)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if res.Reactions != 1200 || res.Comments != 1234 || res.Reposts != 0 {
+			t.Fatalf("expect 1200 reactions, 1234 comments and no reposts, got %d, %d and %d", res.Reactions, res.Comments, res.Reposts)
+		}
+		if len(res.Mentions) != 1 || res.Mentions[0] != "https://www.linkedin.com/in/someone" {
+			t.Fatalf("unexpected mentions %v", res.Mentions)
+		}
+		if len(res.Hashtags) != 1 || res.Hashtags[0] != "golang" {
+			t.Fatalf("unexpected hashtags %v", res.Hashtags)
+		}
+	})
+}
+
+func TestParsePostContent(t *testing.T) {
+	res, err := adapters.ExtractPost(dom)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !strings.HasPrefix(res.Content, "Bora de Vagas Remotas em Dólar 💰?\n\nDa uma olhadinha") {
+		t.Fatalf("expect paragraphs kept from line breaks, got %q", res.Content[:80])
+	}
+	if !strings.HasSuffix(res.Content, "#tammyindica #vagas #remoto") || strings.Contains(res.Content, "  ") {
+		t.Fatalf("expect normalized whitespace, got %q", res.Content)
+	}
+
+	t.Run("should strip see more and invisible characters keeping emoji", func(t *testing.T) {
+		html := "<li><div class=\"feed-shared-update-v2\" data-urn=\"urn:li:activity:1\"><div class=\"update-components-text\">" +
+			"<span class=\"break-words\">Hiring\u00a0golang \u2764\ufe0f developers<br>  remote\u200b   only 1\ufe0f\u20e3 \ufeffslot \u2026more</span></div></div></li>"
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		res, err := adapters.ExtractPost(dom)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if res.Content != "Hiring golang \u2764\ufe0f developers\nremote only 1\ufe0f\u20e3 slot" {
+			t.Fatalf("unexpected content %q", res.Content)
+		}
+	})
+}
+
+func TestParseContentSkipUnsupportedPost(t *testing.T) {
+	post := func(urn string) string {
+		return `<li><div class="feed-shared-update-v2" data-urn="` + urn + `">
+			<div class="update-components-actor"><div><a class="app-aware-link" href="https://www.linkedin.com/in/someone">
+				<span class="update-components-actor__title"><span><span><span>Someone</span></span></span></span>
+			</a></div></div>
+			<div class="update-components-text"><span class="break-words">Hiring golang developers</span></div>
+		</div></li>`
+	}
+
+	res, err := adapters.ExtractContent([]string{post("urn:li:activity:1"), post("urn:li:sponsoredContent:2")})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(res) != 1 || res[0].Post.Urn != "urn:li:activity:1" {
+		t.Fatalf("expect only activity post, got %+v", res)
+	}
 }
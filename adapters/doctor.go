@@ -0,0 +1,52 @@
+package adapters
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FieldCheck count in how many results a content field was extracted
+type FieldCheck struct {
+	Field string
+	Found int
+	Total int
+}
+
+func (c FieldCheck) Ok() bool {
+	return c.Total > 0 && c.Found == c.Total
+}
+
+// CheckContent extract each field of posts search results, reporting which ones are missing
+func CheckContent(results []string) ([]FieldCheck, error) {
+	fields := []struct {
+		name    string
+		extract func(dom *goquery.Document) string
+	}{
+		{"author.name", func(dom *goquery.Document) string { return find(dom, author_name).First().Text() }},
+		{"author.description", func(dom *goquery.Document) string { return find(dom, author_description).First().Text() }},
+		{"author.url", func(dom *goquery.Document) string { return find(dom, autor_avatar).AttrOr("href", "") }},
+		{"post.urn", func(dom *goquery.Document) string { return find(dom, post_link).First().AttrOr("data-urn", "") }},
+		{"post.content", func(dom *goquery.Document) string { return find(dom, post).First().Text() }},
+		{"post.reactions", func(dom *goquery.Document) string { return find(dom, reactions).First().Text() }},
+		{"post.comments", func(dom *goquery.Document) string { return find(dom, comments).First().Text() }},
+		{"post.relative_time", func(dom *goquery.Document) string { return extractRelativeTime(dom) }},
+	}
+
+	checks := make([]FieldCheck, len(fields))
+	for i, field := range fields {
+		checks[i] = FieldCheck{Field: field.name, Total: len(results)}
+	}
+	for _, v := range results {
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(v))
+		if err != nil {
+			return nil, err
+		}
+		for i, field := range fields {
+			if strings.TrimSpace(field.extract(dom)) != "" {
+				checks[i].Found++
+			}
+		}
+	}
+	return checks, nil
+}
@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+// keys of selectors shared by people and companies search results
+const (
+	entity_title     = "entity.title"
+	entity_link      = "entity.link"
+	entity_primary   = "entity.primary"
+	entity_secondary = "entity.secondary"
+	entity_badge     = "entity.badge"
+	entity_image     = "entity.image"
+)
+
+func entityName(dom *goquery.Document) string {
+	name := find(dom, entity_title).First().Text()
+	if name == "" {
+		name = find(dom, entity_link).First().Text()
+	}
+	return strings.TrimSpace(name)
+}
+
+func ExtractPerson(dom *goquery.Document) (*domain.Author, error) {
+	url, hasUrl := find(dom, entity_link).First().Attr("href")
+	if !hasUrl {
+		return nil, nil
+	}
+	author := &domain.Author{
+		Name:        entityName(dom),
+		Description: strings.TrimSpace(find(dom, entity_primary).First().Text()),
+		Url:         url,
+		AvatarUrl:   find(dom, entity_image).First().AttrOr("src", ""),
+		Degree:      parseDegree(find(dom, entity_badge).First().Text()),
+	}
+	return author, nil
+}
+
+func ExtractCompany(dom *goquery.Document) (*domain.Company, error) {
+	url, hasUrl := find(dom, entity_link).First().Attr("href")
+	if !hasUrl {
+		return nil, nil
+	}
+	company := &domain.Company{
+		Name:        entityName(dom),
+		Description: strings.TrimSpace(find(dom, entity_primary).First().Text()),
+		Followers:   strings.TrimSpace(find(dom, entity_secondary).First().Text()),
+		Url:         url,
+	}
+	return company, nil
+}
+
+func ExtractPeople(results []string) (people []domain.Author, err error) {
+	for _, v := range results {
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(v))
+		if err != nil {
+			return nil, err
+		}
+		person, err := ExtractPerson(dom)
+		if err != nil {
+			return nil, err
+		}
+		if person != nil {
+			people = append(people, *person)
+		}
+	}
+	return people, nil
+}
+
+func ExtractCompanies(results []string) (companies []domain.Company, err error) {
+	for _, v := range results {
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(v))
+		if err != nil {
+			return nil, err
+		}
+		company, err := ExtractCompany(dom)
+		if err != nil {
+			return nil, err
+		}
+		if company != nil {
+			companies = append(companies, *company)
+		}
+	}
+	return companies, nil
+}
@@ -0,0 +1,49 @@
+package adapters
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+// keys of selectors used to parse jobs, see selectors/selectors.toml
+const (
+	job_card     = "job.card"
+	job_title    = "job.title"
+	job_company  = "job.company"
+	job_location = "job.location"
+	jobViewUrl   = "https://www.linkedin.com/jobs/view/"
+)
+
+func ExtractJob(dom *goquery.Document) (*domain.Job, error) {
+	jobId, hasId := find(dom, job_card).First().Attr("data-occludable-job-id")
+	if !hasId || jobId == "" {
+		return nil, nil
+	}
+	job := &domain.Job{
+		JobId:    jobId,
+		Url:      jobViewUrl + jobId + "/",
+		Title:    strings.TrimSpace(find(dom, job_title).First().Text()),
+		Company:  strings.TrimSpace(find(dom, job_company).First().Text()),
+		Location: strings.TrimSpace(find(dom, job_location).First().Text()),
+	}
+	return job, nil
+}
+
+func ExtractJobs(results []string) (jobs []domain.Job, err error) {
+	for _, v := range results {
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(v))
+		if err != nil {
+			return nil, err
+		}
+		job, err := ExtractJob(dom)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+// keys of selectors used to parse post attachments, see selectors/selectors.toml
+const (
+	media_image           = "content.image"
+	media_video           = "content.video"
+	media_document        = "content.document"
+	media_document_title  = "content.document_title"
+	media_document_config = "content.document_config"
+	media_poll_option     = "content.poll_option"
+	media_link            = "content.link"
+	media_link_title      = "content.link_title"
+	media_link_domain     = "content.link_domain"
+)
+
+// mediaSource returns the first non empty attribute with address of media, skipping blob urls of videos
+func mediaSource(selection *goquery.Selection, attributes ...string) string {
+	for _, attribute := range attributes {
+		if value := strings.TrimSpace(selection.AttrOr(attribute, "")); value != "" && !strings.HasPrefix(value, "blob:") {
+			return value
+		}
+	}
+	return ""
+}
+
+func text(selection *goquery.Selection) string {
+	return strings.Join(strings.Fields(selection.First().Text()), " ")
+}
+
+// documentConfig is the json of document carousel with addresses of the uploaded file
+type documentConfig struct {
+	Doc struct {
+		TranscribedDocumentUrl string `json:"transcribedDocumentUrl"`
+	} `json:"doc"`
+}
+
+// documentUrl returns the pdf address from document config, empty when config is missing or invalid
+func documentUrl(config string) string {
+	var parsed documentConfig
+	if err := json.Unmarshal([]byte(config), &parsed); err != nil {
+		return ""
+	}
+	return parsed.Doc.TranscribedDocumentUrl
+}
+
+// ExtractMedia detect images, videos, documents, polls and link previews attached to post
+func ExtractMedia(dom *goquery.Document) (media []domain.Media) {
+	find(dom, media_image).Each(func(_ int, image *goquery.Selection) {
+		if src := mediaSource(image, "src", "data-delayed-url"); src != "" {
+			media = append(media, domain.Media{Type: domain.MediaImage, Url: src})
+		}
+	})
+
+	if video := find(dom, media_video).First(); video.Length() > 0 {
+		media = append(media, domain.Media{Type: domain.MediaVideo, Url: mediaSource(video, "src", "poster")})
+	}
+
+	if document := find(dom, media_document).First(); document.Length() > 0 {
+		// iframe only has the viewer page, the pdf address is in the document config of carousel
+		address := documentUrl(find(dom, media_document_config).First().AttrOr("data-native-document-config", ""))
+		if address == "" {
+			address = mediaSource(document, "data-src", "src")
+		}
+		media = append(media, domain.Media{
+			Type:  domain.MediaDocument,
+			Url:   address,
+			Title: text(find(dom, media_document_title)),
+		})
+	}
+
+	if options := find(dom, media_poll_option); options.Length() > 0 {
+		poll := domain.Media{Type: domain.MediaPoll}
+		options.Each(func(_ int, option *goquery.Selection) {
+			if value := text(option); value != "" {
+				poll.Options = append(poll.Options, value)
+			}
+		})
+		media = append(media, poll)
+	}
+
+	if link := find(dom, media_link).First(); link.Length() > 0 {
+		preview := domain.Media{
+			Type:   domain.MediaLink,
+			Url:    mediaSource(link, "href"),
+			Title:  text(find(dom, media_link_title)),
+			Domain: text(find(dom, media_link_domain)),
+		}
+		if parsed, err := url.Parse(preview.Url); err == nil && preview.Domain == "" {
+			preview.Domain = strings.TrimPrefix(parsed.Hostname(), "www.")
+		}
+		media = append(media, preview)
+	}
+	return media
+}
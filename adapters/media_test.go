@@ -0,0 +1,76 @@
+package adapters_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/victorfernandesraton/lazydin/adapters"
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+func TestExtractMedia(t *testing.T) {
+	t.Run("should not find media in text post", func(t *testing.T) {
+		if media := adapters.ExtractMedia(dom); len(media) != 0 {
+			t.Fatalf("expect no media, got %v", media)
+		}
+	})
+
+	t.Run("should find attachments", func(t *testing.T) {
+		html := `<li><div class="feed-shared-update-v2" data-urn="urn:li:activity:1">
+			<div class="update-components-image"><img src="https://media.licdn.com/one.jpg"><img data-delayed-url="https://media.licdn.com/two.jpg"></div>
+			<div class="update-components-linkedin-video"><video src="blob:https://www.linkedin.com/1" poster="https://media.licdn.com/poster.jpg"></video></div>
+			<div class="update-components-document__container">
+				<div class="document-s-container" data-native-document-config='{"doc":{"title":"Golang roadmap","manifestUrl":"https://media.licdn.com/dms/document/pl/D4D10AQ/manifest","transcribedDocumentUrl":"https://media.licdn.com/dms/document/media/D4D1FAQ/feedshare-document-pdf-analyzed/0/1700000000000?e=1&t=x"}}'>
+					<div class="update-components-document__title">Golang  roadmap</div>
+					<iframe class="document-s-container__document-element" data-src="https://www.linkedin.com/feed/update/urn:li:activity:1?doc=1" src="about:blank"></iframe>
+				</div>
+			</div>
+			<div class="update-components-poll">
+				<span class="update-components-poll-option__text">Go</span>
+				<span class="update-components-poll-option__text">Rust</span>
+			</div>
+			<div class="update-components-article"><a href="https://www.example.com/post">
+				<span class="update-components-article__title">Some article</span>
+			</a></div>
+		</div></li>`
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		media := adapters.ExtractMedia(dom)
+		expected := []domain.Media{
+			{Type: domain.MediaImage, Url: "https://media.licdn.com/one.jpg"},
+			{Type: domain.MediaImage, Url: "https://media.licdn.com/two.jpg"},
+			{Type: domain.MediaVideo, Url: "https://media.licdn.com/poster.jpg"},
+			{Type: domain.MediaDocument, Url: "https://media.licdn.com/dms/document/media/D4D1FAQ/feedshare-document-pdf-analyzed/0/1700000000000?e=1&t=x", Title: "Golang roadmap"},
+			{Type: domain.MediaPoll, Options: domain.List{"Go", "Rust"}},
+			{Type: domain.MediaLink, Url: "https://www.example.com/post", Title: "Some article", Domain: "example.com"},
+		}
+		if len(media) != len(expected) {
+			t.Fatalf("expect %d media, got %v", len(expected), media)
+		}
+		for i := range expected {
+			if media[i].Type != expected[i].Type || media[i].Url != expected[i].Url || media[i].Title != expected[i].Title ||
+				media[i].Domain != expected[i].Domain || media[i].Options.String() != expected[i].Options.String() {
+				t.Fatalf("expect %+v, got %+v", expected[i], media[i])
+			}
+		}
+	})
+
+	t.Run("should not download document without pdf address", func(t *testing.T) {
+		html := `<li><div class="feed-shared-update-v2" data-urn="urn:li:activity:1">
+			<div class="update-components-document__container"><div class="document-s-container">
+				<iframe class="document-s-container__document-element" data-src="https://www.linkedin.com/feed/update/urn:li:activity:1?doc=1"></iframe>
+			</div></div>
+		</div></li>`
+		dom, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		media := adapters.ExtractMedia(dom)
+		if len(media) != 1 || media[0].Type != domain.MediaDocument || media[0].Downloadable() {
+			t.Fatalf("expect document viewer not downloadable, got %+v", media)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package adapters_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/adapters"
+)
+
+func readTestdata(t *testing.T, name string) string {
+	content, err := os.ReadFile(filepath.Join("..", "testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test data file: %s", err.Error())
+	}
+	return string(content)
+}
+
+func TestExtractPeople(t *testing.T) {
+	res, err := adapters.ExtractPeople([]string{readTestdata(t, "people.html")})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(res) != 1 {
+		t.Fatalf("expect %d people, got %d", 1, len(res))
+	}
+	if res[0].Name != "Jane Doe" {
+		t.Fatalf("expect name %s, got %s", "Jane Doe", res[0].Name)
+	}
+	if res[0].Description != "Tech Recruiter at Acme | Hiring Golang developers" {
+		t.Fatalf("unexpected description %s", res[0].Description)
+	}
+	if res[0].Url == "" {
+		t.Fatalf("Not found url")
+	}
+	if res[0].Degree != 2 || res[0].AvatarUrl != "https://media.licdn.com/dms/image/jane.jpg" {
+		t.Fatalf("expect 2nd degree and avatar, got %d and %s", res[0].Degree, res[0].AvatarUrl)
+	}
+}
+
+func TestExtractCompanies(t *testing.T) {
+	res, err := adapters.ExtractCompanies([]string{readTestdata(t, "companies.html")})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(res) != 1 {
+		t.Fatalf("expect %d companies, got %d", 1, len(res))
+	}
+	if res[0].Name != "Acme Corp" {
+		t.Fatalf("expect name %s, got %s", "Acme Corp", res[0].Name)
+	}
+	if res[0].Followers != "12K followers" {
+		t.Fatalf("unexpected followers %s", res[0].Followers)
+	}
+	if res[0].Url != "https://www.linkedin.com/company/acme/" {
+		t.Fatalf("unexpected url %s", res[0].Url)
+	}
+}
+
+func TestExtractJobs(t *testing.T) {
+	res, err := adapters.ExtractJobs([]string{readTestdata(t, "jobs.html")})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(res) != 1 {
+		t.Fatalf("expect %d jobs, got %d", 1, len(res))
+	}
+	job := res[0]
+	if job.JobId != "3912345678" || job.Url != "https://www.linkedin.com/jobs/view/3912345678/" {
+		t.Fatalf("unexpected job id %s and url %s", job.JobId, job.Url)
+	}
+	if job.Title != "Senior Golang Engineer" || job.Company != "Acme Corp" || job.Location != "Brazil (Remote)" {
+		t.Fatalf("unexpected job %v", job)
+	}
+}
@@ -0,0 +1,17 @@
+package adapters
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+// find returns elements of the first fallback of selector key matching in dom
+func find(dom *goquery.Document, key string) *goquery.Selection {
+	selection := dom.Selection.Slice(0, 0)
+	for _, fallback := range selectors.Get(key).Fallbacks {
+		if selection = dom.Find(fallback); selection.Length() > 0 {
+			return selection
+		}
+	}
+	return selection
+}
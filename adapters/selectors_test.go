@@ -0,0 +1,33 @@
+package adapters_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/adapters"
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+func TestSelectorFallbacks(t *testing.T) {
+	defer selectors.Reset()
+	file := filepath.Join(t.TempDir(), "selectors.toml")
+	content := `
+[entity]
+title = ["li .new-entity-title", "li .entity-result__title-text a span[aria-hidden='true']"]
+`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := selectors.Load(file); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	people, err := adapters.ExtractPeople([]string{readTestdata(t, "people.html")})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(people) != 1 || people[0].Name != "Jane Doe" {
+		t.Fatalf("expect name found by second fallback, got %v", people)
+	}
+}
@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// lineBreak is a private use rune replacing <br> while reading text, so line breaks survive whitespace normalization
+const lineBreak = "\ue000"
+
+var (
+	// seeMoreRegex match leftover text of see more buttons, like "…more", "... see more" or "…mais"
+	seeMoreRegex = regexp.MustCompile(`(?i)\s*(?:…|\.\.\.)\s*(?:see more|more|ver mais|mais)\s*$`)
+	// invisibleReplacer remove zero width spaces and byte order marks and turn non breaking spaces into spaces,
+	// emoji variation selectors and joiners are kept so emoji look the same as in linkedin
+	invisibleReplacer = strings.NewReplacer("\u200b", "", "\ufeff", "", "\u00a0", " ")
+)
+
+// cleanText read text of selection keeping line breaks from <br>, collapsing whitespace of html indentation,
+// removing see more text and invisible characters
+func cleanText(selection *goquery.Selection) string {
+	selection = selection.First().Clone()
+	selection.Find("br").ReplaceWithHtml(lineBreak)
+
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(invisibleReplacer.Replace(selection.Text()), lineBreak) {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return seeMoreRegex.ReplaceAllString(strings.Join(lines, "\n"), "")
+}
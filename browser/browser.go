@@ -1,12 +1,32 @@
 package browser
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/chromedp/chromedp"
 )
 
 type BrowserOptions struct {
-	Maximized bool
-	Headless  bool
+	Maximized   bool
+	Headless    bool
+	UserDataDir string
+	// WindowWidth and WindowHeight define window size, zero keep Maximized
+	WindowWidth  int
+	WindowHeight int
+	// ExecPath of chrome or chromium, empty look for it in PATH
+	ExecPath  string
+	UserAgent string
+	// Proxy like http://host:3128 or socks5://host:1080
+	Proxy  string
+	Locale string
+	// ExtraFlags are chrome flags like disable-gpu or window-position=0,0
+	ExtraFlags []string
+	// RemoteUrl is the remote debugging url (ws://host:9222/...) of a running chrome,
+	// when set chrome is not started and options above are ignored
+	RemoteUrl string
 }
 
 func DefaultBrowserOptions() BrowserOptions {
@@ -17,8 +37,85 @@ func DefaultBrowserOptions() BrowserOptions {
 }
 
 func CreateBrowserOptions(options BrowserOptions) []chromedp.ExecAllocatorOption {
-	return append(chromedp.DefaultExecAllocatorOptions[:],
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.Flag("headless", options.Headless),
-		chromedp.Flag("start-maximized", options.Maximized),
 	)
+	if options.WindowWidth > 0 && options.WindowHeight > 0 {
+		opts = append(opts, chromedp.WindowSize(options.WindowWidth, options.WindowHeight))
+	} else {
+		opts = append(opts, chromedp.Flag("start-maximized", options.Maximized))
+	}
+	if options.UserDataDir != "" {
+		opts = append(opts, chromedp.UserDataDir(options.UserDataDir))
+	}
+	if options.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(options.ExecPath))
+	}
+	if options.UserAgent != "" {
+		opts = append(opts, chromedp.UserAgent(options.UserAgent))
+	}
+	if options.Proxy != "" {
+		opts = append(opts, chromedp.ProxyServer(options.Proxy))
+	}
+	if options.Locale != "" {
+		opts = append(opts,
+			chromedp.Flag("lang", options.Locale),
+			chromedp.Flag("accept-lang", options.Locale),
+		)
+	}
+	for _, flag := range options.ExtraFlags {
+		name, value := parseFlag(flag)
+		if name != "" {
+			opts = append(opts, chromedp.Flag(name, value))
+		}
+	}
+	return opts
+}
+
+// NewContext creates the chrome allocator, starting chrome or connecting to a running one
+// with RemoteUrl, and the chromedp context used by workflows
+func NewContext(options BrowserOptions, opts ...chromedp.ContextOption) (context.Context, context.CancelFunc) {
+	var actx context.Context
+	var acancel context.CancelFunc
+	if options.RemoteUrl != "" {
+		actx, acancel = chromedp.NewRemoteAllocator(context.Background(), options.RemoteUrl)
+	} else {
+		actx, acancel = chromedp.NewExecAllocator(context.Background(), CreateBrowserOptions(options)...)
+	}
+	ctx, cancel := chromedp.NewContext(actx, opts...)
+	return ctx, func() {
+		cancel()
+		acancel()
+	}
+}
+
+// parseFlag split a chrome flag like --window-position=0,0 in name and value,
+// flags without value are enabled
+func parseFlag(flag string) (string, any) {
+	name, value, hasValue := strings.Cut(strings.TrimLeft(strings.TrimSpace(flag), "-"), "=")
+	if !hasValue {
+		return name, true
+	}
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return name, enabled
+	}
+	return name, value
+}
+
+// ParseWindowSize read a window size like 1280x800, empty size returns zeros
+func ParseWindowSize(size string) (width, height int, err error) {
+	if strings.TrimSpace(size) == "" {
+		return 0, 0, nil
+	}
+	w, h, ok := strings.Cut(strings.ToLower(strings.TrimSpace(size)), "x")
+	if ok {
+		width, err = strconv.Atoi(strings.TrimSpace(w))
+		if err == nil {
+			height, err = strconv.Atoi(strings.TrimSpace(h))
+		}
+	}
+	if !ok || err != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid window size %q, expect something like 1280x800", size)
+	}
+	return width, height, nil
 }
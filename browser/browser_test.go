@@ -0,0 +1,41 @@
+package browser
+
+import "testing"
+
+func TestParseWindowSize(t *testing.T) {
+	width, height, err := ParseWindowSize("1280x800")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if width != 1280 || height != 800 {
+		t.Fatalf("expect 1280x800, got %dx%d", width, height)
+	}
+
+	if width, height, err := ParseWindowSize(""); err != nil || width != 0 || height != 0 {
+		t.Fatalf("expect empty size to keep zeros, got %dx%d %v", width, height, err)
+	}
+
+	for _, size := range []string{"1280", "1280x", "wide", "0x800"} {
+		if _, _, err := ParseWindowSize(size); err == nil {
+			t.Fatalf("expect error for %s", size)
+		}
+	}
+}
+
+func TestParseFlag(t *testing.T) {
+	cases := []struct {
+		flag  string
+		name  string
+		value any
+	}{
+		{"disable-gpu", "disable-gpu", true},
+		{"--window-position=0,0", "window-position", "0,0"},
+		{"enable-automation=false", "enable-automation", false},
+	}
+	for _, c := range cases {
+		name, value := parseFlag(c.flag)
+		if name != c.name || value != c.value {
+			t.Fatalf("expect %s=%v for %s, got %s=%v", c.name, c.value, c.flag, name, value)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+const configArtifacts = "artifacts"
+
+func DefaultArtifacts(configPath string) {
+	viper.SetDefault(configArtifacts, filepath.Join(configPath, "artifacts"))
+}
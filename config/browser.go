@@ -0,0 +1,30 @@
+package config
+
+import "github.com/spf13/viper"
+
+const (
+	configHeadless   = "browser.headless"
+	configWindowSize = "browser.window_size"
+)
+
+// BrowserConfig holds how chrome is started, empty values keep chrome defaults
+type BrowserConfig struct {
+	Headless bool `mapstructure:"headless"`
+	// WindowSize like 1280x800, empty start maximized
+	WindowSize string `mapstructure:"window_size"`
+	// ExecPath of chrome or chromium, empty look for it in PATH
+	ExecPath  string `mapstructure:"exec_path"`
+	UserAgent string `mapstructure:"user_agent"`
+	// Proxy like http://host:3128 or socks5://host:1080
+	Proxy  string `mapstructure:"proxy"`
+	Locale string `mapstructure:"locale"`
+	// Flags are extra chrome flags like disable-gpu or window-position=0,0
+	Flags []string `mapstructure:"flags"`
+	// RemoteUrl like ws://127.0.0.1:9222/devtools/browser/..., connect to a running chrome instead of start one
+	RemoteUrl string `mapstructure:"remote_url"`
+}
+
+func DefaultBrowser() {
+	viper.SetDefault(configHeadless, false)
+	viper.SetDefault(configWindowSize, "")
+}
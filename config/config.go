@@ -10,8 +10,15 @@ import (
 
 // Config struct holds the configuration options for the application
 type Config struct {
-	Credentials CredentialsConfig `mapstructure:"credentials"`
-	SQlite      string            `mapstructure:"storage"`
+	Credentials CredentialsConfig      `mapstructure:"credentials"`
+	SQlite      string                 `mapstructure:"storage"`
+	Pacing      map[string]DelayConfig `mapstructure:"pacing"`
+	Quota       map[string]QuotaConfig `mapstructure:"quota"`
+	Linkedin    LinkedinConfig         `mapstructure:"linkedin"`
+	Browser     BrowserConfig          `mapstructure:"browser"`
+	Steps       StepsConfig            `mapstructure:"steps"`
+	// Artifacts is where screenshot, html and console log of failed workflows are saved
+	Artifacts string `mapstructure:"artifacts"`
 }
 
 // LoadConfig loads the configuration from file or environment variables
@@ -30,6 +37,12 @@ func LoadConfig() (*Config, error) {
 
 	DefaultCredentials()
 	DefaultStorage(appPath)
+	DefaultPacing()
+	DefaultQuota()
+	DefaultLinkedin()
+	DefaultArtifacts(appPath)
+	DefaultBrowser()
+	DefaultSteps()
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
@@ -51,3 +64,8 @@ func LoadConfig() (*Config, error) {
 
 	return &cfg, nil
 }
+
+// ConfigFile returns the config file in use
+func ConfigFile() string {
+	return viper.ConfigFileUsed()
+}
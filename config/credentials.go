@@ -9,28 +9,43 @@ import (
 const (
 	configUsername = "credentials.username"
 	configPassword = "credentials.password"
+	configTotp     = "credentials.totp_secret"
+
+	defaultUsername = "user@mail.com"
+	defaultPassword = "user.pass"
 )
 
 // CredentialsConfig holds the credentials for the application
 type CredentialsConfig struct {
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
+	// TotpSecret is the base32 secret of authenticator app, used to complete 2FA
+	TotpSecret string `mapstructure:"totp_secret"`
 }
 
 type Credentials struct {
-	Username string
-	Password string
+	Username   string
+	Password   string
+	TotpSecret string
+}
+
+// IsDefault check if credentials are still the placeholders written in new config
+func (c Credentials) IsDefault() bool {
+	return c.Username == defaultUsername && c.Password == defaultPassword
 }
 
 func DefaultCredentials() {
 	// Set default values for configuration options if necessary
-	viper.SetDefault(configUsername, "user@mail.com")
-	viper.SetDefault(configPassword, "user.pass")
+	viper.SetDefault(configUsername, defaultUsername)
+	viper.SetDefault(configPassword, defaultPassword)
 }
 
-func SetCredentials(username, password string) error {
+func SetCredentials(username, password, totpSecret string) error {
 	viper.Set(configUsername, username)
 	viper.Set(configPassword, password)
+	if totpSecret != "" {
+		viper.Set(configTotp, totpSecret)
+	}
 	return viper.WriteConfig()
 }
 
@@ -45,7 +60,11 @@ func LoadCredentials(config *Config, flagUsername, flagPassword string) (*Creden
 	if flagPassword != "" {
 		envPassword = flagPassword
 	}
-	credentials := &Credentials{Username: envUsername, Password: envPassword}
+	credentials := &Credentials{
+		Username:   envUsername,
+		Password:   envPassword,
+		TotpSecret: config.Credentials.TotpSecret,
+	}
 
 	if credentials.Username == "" || credentials.Password == "" {
 		return nil, errors.New(
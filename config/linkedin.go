@@ -0,0 +1,14 @@
+package config
+
+import "github.com/spf13/viper"
+
+const configBaseUrl = "linkedin.base_url"
+
+// LinkedinConfig holds where linkedin is reached, useful to run against a local mock server
+type LinkedinConfig struct {
+	BaseUrl string `mapstructure:"base_url"`
+}
+
+func DefaultLinkedin() {
+	viper.SetDefault(configBaseUrl, "https://www.linkedin.com")
+}
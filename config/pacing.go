@@ -0,0 +1,34 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const configPacing = "pacing"
+
+// DelayConfig is a random delay between Min and Max, for mutating actions
+// (follow, connect, comment and message) it is the minimum interval between them,
+// kept between runs in the database
+type DelayConfig struct {
+	Min time.Duration `mapstructure:"min"`
+	Max time.Duration `mapstructure:"max"`
+}
+
+var defaultPacing = map[string]DelayConfig{
+	"typing":   {Min: 60 * time.Millisecond, Max: 220 * time.Millisecond},
+	"navigate": {Min: 2 * time.Second, Max: 5 * time.Second},
+	"click":    {Min: 500 * time.Millisecond, Max: 2 * time.Second},
+	"follow":   {Min: 30 * time.Second, Max: 90 * time.Second},
+	"connect":  {Min: 60 * time.Second, Max: 180 * time.Second},
+	"comment":  {Min: 60 * time.Second, Max: 180 * time.Second},
+	"message":  {Min: 60 * time.Second, Max: 180 * time.Second},
+}
+
+func DefaultPacing() {
+	for action, delay := range defaultPacing {
+		viper.SetDefault(configPacing+"."+action+".min", delay.Min.String())
+		viper.SetDefault(configPacing+"."+action+".max", delay.Max.String())
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import "github.com/spf13/viper"
+
+const configQuota = "quota"
+
+// QuotaConfig holds the max actions by day and by week (last 7 days), zero means no limit
+type QuotaConfig struct {
+	Daily  int `mapstructure:"daily"`
+	Weekly int `mapstructure:"weekly"`
+}
+
+var defaultQuota = map[string]QuotaConfig{
+	"follow":  {Daily: 30, Weekly: 150},
+	"connect": {Daily: 15, Weekly: 80},
+	"comment": {Daily: 10, Weekly: 50},
+	"message": {Daily: 15, Weekly: 80},
+}
+
+func DefaultQuota() {
+	for action, quota := range defaultQuota {
+		viper.SetDefault(configQuota+"."+action+".daily", quota.Daily)
+		viper.SetDefault(configQuota+"."+action+".weekly", quota.Weekly)
+	}
+}
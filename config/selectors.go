@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const selectorsFile = "selectors.toml"
+
+// SelectorsFile returns the file where users override the default linkedin selectors
+func SelectorsFile() (string, error) {
+	home, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "lazydin", selectorsFile), nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const sessionsDir = "sessions"
+
+var unsafeSessionChars = regexp.MustCompile(`[^a-zA-Z0-9._@-]`)
+
+// SessionDir returns the chrome user data dir used to keep the session of an account
+func SessionDir(username string) (string, error) {
+	if username == "" {
+		return "", errors.New("username is required to find session")
+	}
+	home, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "lazydin", sessionsDir, unsafeSessionChars.ReplaceAllString(username, "_")), nil
+}
+
+// ClearSession removes the stored browser session of an account
+func ClearSession(username string) error {
+	sessionPath, err := SessionDir(username)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(sessionPath)
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	configStepsTimeout = "steps.timeout"
+	configStepsRetries = "steps.retries"
+	configStepsBackoff = "steps.backoff"
+)
+
+// StepsConfig holds the timeout of each kind of browser step (navigate, wait, click,
+// type and login) and how many times flaky steps are retried, zero timeout means no limit
+type StepsConfig struct {
+	Timeout map[string]time.Duration `mapstructure:"timeout"`
+	Retries int                      `mapstructure:"retries"`
+	// Backoff is the pause before first retry, doubled in each retry
+	Backoff time.Duration `mapstructure:"backoff"`
+}
+
+var defaultStepTimeouts = map[string]time.Duration{
+	"navigate": time.Minute,
+	"wait":     30 * time.Second,
+	"click":    30 * time.Second,
+	"type":     30 * time.Second,
+	"login":    5 * time.Minute,
+}
+
+func DefaultSteps() {
+	for kind, timeout := range defaultStepTimeouts {
+		viper.SetDefault(configStepsTimeout+"."+kind, timeout.String())
+	}
+	viper.SetDefault(configStepsRetries, 3)
+	viper.SetDefault(configStepsBackoff, time.Second.String())
+}
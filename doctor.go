@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/spf13/cobra"
+	"github.com/victorfernandesraton/lazydin/adapters"
+	"github.com/victorfernandesraton/lazydin/browser"
+	"github.com/victorfernandesraton/lazydin/config"
+	"github.com/victorfernandesraton/lazydin/selectors"
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+const (
+	doctorBrowserTimeout = 30 * time.Second
+	doctorResultsTimeout = 20 * time.Second
+)
+
+var (
+	// searchPageSelectors are prefixes of keys found in posts search results page, the only page checked by doctor
+	searchPageSelectors = []string{"nav.", "search.post", "search.see_more", "content."}
+	// optionalSelectors only match when some post has them, like see more in long posts or images,
+	// so missing ones are skipped instead of failing
+	optionalSelectors = []string{
+		"search.see_more", "content.comments", "content.reposts", "content.hashtags", "content.mentions",
+		"content.author_degree", "content.author_followers", "content.author_verified", "content.author_premium",
+		"content.image", "content.video", "content.document", "content.document_config", "content.poll_option", "content.link",
+	}
+)
+
+// doctorReport print one line per check, counting failures
+type doctorReport struct {
+	writer   *tabwriter.Writer
+	failures int
+}
+
+func newDoctorReport() *doctorReport {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "CHECK\tSTATUS\tDETAIL")
+	return &doctorReport{writer: writer}
+}
+
+func (r *doctorReport) add(name string, ok bool, detail string) {
+	status := "ok"
+	if !ok {
+		status = "fail"
+		r.failures++
+	}
+	fmt.Fprintf(r.writer, "%s\t%s\t%s\n", name, status, detail)
+}
+
+// skip report a check that was not done, without counting it as failure
+func (r *doctorReport) skip(name, detail string) {
+	fmt.Fprintf(r.writer, "%s\t%s\t%s\n", name, "skip", detail)
+}
+
+func (r *doctorReport) addError(name string, err error, detail string) {
+	if err != nil {
+		r.add(name, false, err.Error())
+		return
+	}
+	r.add(name, true, detail)
+}
+
+// runDoctor check environment and selectors before a real run
+// this function handle for doctor command, args are optional selector key prefixes like search or content
+func runDoctor(cmd *cobra.Command, args []string) error {
+	htmlFile, err := cmd.Flags().GetString(flagHtml)
+	if err != nil {
+		return fmt.Errorf("failed to get html flag: %w", err)
+	}
+	live, err := cmd.Flags().GetBool(flagLive)
+	if err != nil {
+		return fmt.Errorf("failed to get live flag: %w", err)
+	}
+	query, err := cmd.Flags().GetString(flagQuery)
+	if err != nil {
+		return fmt.Errorf("failed to get query flag: %w", err)
+	}
+	if htmlFile != "" && live {
+		return errors.New("use either html or live, not both")
+	}
+
+	report := newDoctorReport()
+	chromeErr := checkChrome()
+	chromeDetail := "found by chromedp allocator"
+	if options, err := loadBrowserOptions(); err == nil && options.RemoteUrl != "" {
+		chromeDetail = "connected to " + options.RemoteUrl
+	}
+	report.addError("chrome", chromeErr, chromeDetail)
+	report.addError("config", checkReadable(config.ConfigFile()), config.ConfigFile())
+	report.addError("storage", checkStorage(), configs.SQlite)
+
+	credentials, err := loadCredentials()
+	if err == nil && credentials.IsDefault() {
+		err = errors.New("credentials still have placeholder values, run create-credentials")
+	}
+	report.addError("credentials", err, "set")
+
+	selectorsFile, err := config.SelectorsFile()
+	if err == nil {
+		detail := fmt.Sprintf("version %d, embedded defaults", selectors.Version())
+		if _, statErr := os.Stat(selectorsFile); statErr == nil {
+			detail = fmt.Sprintf("version %d, overrides from %s", selectors.Version(), selectorsFile)
+		}
+		report.addError("selectors", nil, detail)
+	} else {
+		report.addError("selectors", err, "")
+	}
+
+	if (htmlFile != "" || live) && chromeErr == nil {
+		if err := doctorSelectors(report, htmlFile, query, args); err != nil {
+			report.addError("page", err, "")
+		}
+	}
+
+	if err := report.writer.Flush(); err != nil {
+		return err
+	}
+	if report.failures > 0 {
+		return fmt.Errorf("doctor found %d problems", report.failures)
+	}
+	return nil
+}
+
+// checkChrome start and close a headless chrome, like commands do
+func checkChrome() error {
+	ctx, cancel, err := headlessBrowser()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	return chromedp.Run(ctx)
+}
+
+// headlessBrowser start chrome with browser config, but without window and session
+func headlessBrowser() (context.Context, context.CancelFunc, error) {
+	browserOptions, err := loadBrowserOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+	browserOptions.Headless = true
+	ctx, cancel := browser.NewContext(browserOptions)
+	ctx, timeoutCancel := context.WithTimeout(workflow.WatchConsole(ctx), doctorBrowserTimeout)
+	return ctx, func() {
+		timeoutCancel()
+		cancel()
+	}, nil
+}
+
+func checkReadable(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func checkStorage() error {
+	if err := checkReadable(configs.SQlite); err != nil {
+		return err
+	}
+	return databse.Ping()
+}
+
+// doctorSelectors open a saved html page or a live search and look for every selector
+// and posts field in it
+func doctorSelectors(report *doctorReport, htmlFile, query string, prefixes []string) error {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if htmlFile != "" {
+		absPath, err := filepath.Abs(htmlFile)
+		if err != nil {
+			return err
+		}
+		if err := checkReadable(absPath); err != nil {
+			return err
+		}
+		ctx, cancel, err = headlessBrowser()
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		if err := workflow.Run(ctx, workflow.OpenPage("file://"+absPath)); err != nil {
+			return fmt.Errorf("failed to open %s: %w", absPath, err)
+		}
+	} else {
+		credentials, err := loadCredentials()
+		if err != nil {
+			return err
+		}
+		ctx, cancel, err = startBrowser(credentials)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		if err := workflow.Run(ctx,
+			workflow.Login(credentials.Username, credentials.Password, challengeResolver(credentials)),
+		); err != nil {
+			return fmt.Errorf("failed to login: %w", err)
+		}
+		// broken post selectors are reported below, so waiting results only until timeout
+		waitCtx, waitCancel := context.WithTimeout(ctx, doctorResultsTimeout)
+		chromedp.Run(waitCtx, workflow.SearchForPosts(workflow.PostSearch{Query: query}))
+		waitCancel()
+	}
+
+	var keys []string
+	for _, key := range selectors.Keys() {
+		switch {
+		case !hasAnyPrefix(key, prefixes):
+		case len(prefixes) == 0 && !hasAnyPrefix(key, searchPageSelectors):
+			report.skip("selector "+key, "not in posts search page, pass his group as argument to check it")
+		default:
+			keys = append(keys, key)
+		}
+	}
+	checks, err := workflow.CheckSelectors(ctx, keys)
+	if err != nil {
+		return err
+	}
+	postsFound := false
+	for _, check := range checks {
+		switch {
+		case check.Found():
+			report.add("selector "+check.Key, true, fmt.Sprintf("%d found with %s", check.Count, check.Fallback))
+		case slices.Contains(optionalSelectors, check.Key):
+			report.skip("selector "+check.Key, "not found, no post in page has it")
+		default:
+			report.add("selector "+check.Key, false, "not found")
+		}
+		postsFound = postsFound || (check.Key == "search.post" && check.Found())
+	}
+	if !postsFound || !hasAnyPrefix("content", prefixes) {
+		return nil
+	}
+
+	results, err := workflow.ExtractOuterHTML(ctx)
+	if err != nil {
+		return err
+	}
+	fields, err := adapters.CheckContent(results)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		report.add("field "+field.Field, field.Ok(), fmt.Sprintf("%d of %d posts", field.Found, field.Total))
+	}
+	return nil
+}
+
+// hasAnyPrefix check if key starts with some prefix, no prefixes match everything
+func hasAnyPrefix(key string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = 30 * day
+	year  = 365 * day
+)
+
+// ageUnits map units of relative ages shown by linkedin, in english and portuguese, to their duration
+var ageUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"seg": time.Second, "segundo": time.Second, "segundos": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"minuto": time.Minute, "minutos": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"hora": time.Hour, "horas": time.Hour,
+	"d": day, "day": day, "days": day, "dia": day, "dias": day,
+	"w": week, "wk": week, "wks": week, "week": week, "weeks": week,
+	"sem": week, "semana": week, "semanas": week,
+	"mo": month, "mos": month, "month": month, "months": month,
+	"mes": month, "mês": month, "meses": month,
+	"y": year, "yr": year, "yrs": year, "year": year, "years": year,
+	"a": year, "ano": year, "anos": year,
+}
+
+var agePattern = regexp.MustCompile(`^(\d+)\s*([\p{L}]+)\.?(?:\s+(?:ago|atrás))?$`)
+
+// ParseAge convert relative ages like 3h, 2d, 1w, 5mo or 2 sem to duration
+func ParseAge(text string) (time.Duration, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "now" || text == "agora" {
+		return 0, nil
+	}
+	match := agePattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, fmt.Errorf("invalid age, got %q, expected something like 3h, 2d, 1w or 5mo", text)
+	}
+	unit, ok := ageUnits[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid age unit, got %q in %q", match[2], text)
+	}
+	value, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(value) * unit, nil
+}
+
+// PostedAt estimate when a post was published from his relative age and the time it was scraped
+func PostedAt(age string, scrapedAt time.Time) (Timestamp, error) {
+	duration, err := ParseAge(age)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	return NewTimestamp(scrapedAt.Add(-duration)), nil
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+func TestParseAge(t *testing.T) {
+	cases := map[string]time.Duration{
+		"3h":          3 * time.Hour,
+		"45m":         45 * time.Minute,
+		"2d":          48 * time.Hour,
+		"1w":          7 * 24 * time.Hour,
+		"5mo":         5 * 30 * 24 * time.Hour,
+		"1yr":         365 * 24 * time.Hour,
+		"2 sem":       14 * 24 * time.Hour,
+		"3 dias":      72 * time.Hour,
+		"1 mês":       30 * 24 * time.Hour,
+		"10 min":      10 * time.Minute,
+		"2 weeks ago": 14 * 24 * time.Hour,
+		"now":         0,
+	}
+	for age, expected := range cases {
+		res, err := domain.ParseAge(age)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if res != expected {
+			t.Fatalf("expect %s to be %v, got %v", age, expected, res)
+		}
+	}
+
+	for _, age := range []string{"", "Edited", "3 parsecs", "h3"} {
+		if _, err := domain.ParseAge(age); err == nil {
+			t.Fatalf("%q should be invalid age", age)
+		}
+	}
+}
+
+func TestPostedAt(t *testing.T) {
+	scrapedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	res, err := domain.PostedAt("2d", scrapedAt)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !res.Equal(time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected posted at %v", res)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+const listSeparator = ","
+
+// List is a list of values, like hashtags, stored and exported as a comma separated text
+type List []string
+
+func NewList(text string) List {
+	var list List
+	for _, item := range strings.Split(text, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (l List) String() string {
+	return strings.Join(l, listSeparator)
+}
+
+func (l List) MarshalCSV() (string, error) {
+	return l.String(), nil
+}
+
+func (l *List) UnmarshalCSV(text string) error {
+	*l = NewList(text)
+	return nil
+}
+
+func (l List) Value() (driver.Value, error) {
+	return l.String(), nil
+}
+
+func (l *List) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*l = nil
+	case string:
+		*l = NewList(value)
+	case []byte:
+		*l = NewList(string(value))
+	default:
+		return fmt.Errorf("can not scan %T into list", src)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gocarina/gocsv"
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+func TestList(t *testing.T) {
+	t.Run("should ignore empty items", func(t *testing.T) {
+		list := domain.NewList(" vagas, ,remoto,")
+		if !reflect.DeepEqual(list, domain.List{"vagas", "remoto"}) {
+			t.Fatalf("unexpected list %v", list)
+		}
+	})
+
+	t.Run("should export and read list in csv", func(t *testing.T) {
+		posts := []domain.Post{{Url: "some_url", Hashtags: domain.List{"vagas", "remoto"}}}
+		text, err := gocsv.MarshalString(&posts)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if !strings.Contains(text, "\"vagas,remoto\"") {
+			t.Fatalf("expect hashtags joined in csv, got %s", text)
+		}
+		var result []domain.Post
+		if err := gocsv.UnmarshalString(text, &result); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if !reflect.DeepEqual(result[0].Hashtags, posts[0].Hashtags) {
+			t.Fatalf("expect %v, got %v", posts[0].Hashtags, result[0].Hashtags)
+		}
+		if !result[0].PostedAt.IsZero() {
+			t.Fatalf("expect unknown posted at, got %v", result[0].PostedAt)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// MaxConnectionNoteLength is the limit linkedin accept in connection request notes
+const MaxConnectionNoteLength = 300
+
+// RenderMessage execute a text/template using content as data,
+// so messages can use fields like {{.Author.Name}} or {{.Post.Url}}
+func RenderMessage(text string, content Content) (string, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, content); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(builder.String()), nil
+}
+
+// ValidateConnectionNote check if note fit in connection request
+func ValidateConnectionNote(note string) error {
+	if length := utf8.RuneCountInString(note); length > MaxConnectionNoteLength {
+		return fmt.Errorf("connection note has %d characters, but max is %d", length, MaxConnectionNoteLength)
+	}
+	return nil
+}
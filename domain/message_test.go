@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+func TestRenderMessage(t *testing.T) {
+	content := domain.Content{
+		Author: domain.Author{Name: "Victor Raton"},
+		Post:   domain.Post{Url: "urn:li:activity:1"},
+	}
+	t.Run("should render author fields", func(t *testing.T) {
+		res, err := domain.RenderMessage("Hi {{.Author.Name}}, about {{.Post.Url}}", content)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if res != "Hi Victor Raton, about urn:li:activity:1" {
+			t.Fatalf("unexpected message %s", res)
+		}
+	})
+
+	t.Run("should fail with invalid field", func(t *testing.T) {
+		if _, err := domain.RenderMessage("Hi {{.Author.Nickname}}", content); err == nil {
+			t.Fatalf("expected error for unknown field")
+		}
+	})
+}
+
+func TestValidateConnectionNote(t *testing.T) {
+	if err := domain.ValidateConnectionNote(strings.Repeat("á", domain.MaxConnectionNoteLength)); err != nil {
+		t.Fatalf("note with max length should be valid: %s", err.Error())
+	}
+	if err := domain.ValidateConnectionNote(strings.Repeat("a", domain.MaxConnectionNoteLength+1)); err == nil {
+		t.Fatalf("expected error for long note")
+	}
+}
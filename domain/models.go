@@ -1,33 +1,174 @@
 package domain
 
-import "time"
+import (
+	"net/url"
+	"strings"
+	"time"
+)
 
 type Post struct {
+	ID  uint64 `csv:"-"`
+	Urn URN    `csv:"urn"`
+	// Url is the public permalink of post, built from Urn
+	Url       string `csv:"url"`
+	Content   string `csv:"content"`
+	AuthorUrl string `csv:"author_url"`
+	AuthorId  uint64 `csv:"-"`
+	Reactions int    `csv:"reactions"`
+	Comments  int    `csv:"comments"`
+	Reposts   int    `csv:"reposts"`
+	// RelativeTime is the post age as shown by linkedin, like 4mo or 2d
+	RelativeTime string `csv:"relative_time"`
+	// PostedAt is estimated from RelativeTime when post was scraped, zero when age is unknown
+	PostedAt Timestamp `csv:"posted_at"`
+	// Hashtags are without #
+	Hashtags List `csv:"hashtags"`
+	// Mentions are profile urls of people mentioned in post
+	Mentions List `csv:"mentions"`
+	// Media are images, videos, documents, polls and link previews attached to post
+	Media     []Media   `csv:"-"`
+	CreatedAt time.Time `csv:"-"`
+	UpdatedAt time.Time `csv:"-"`
+}
+
+type Author struct {
+	ID          uint64 `csv:"-"`
+	Url         string `csv:"url"`
+	Name        string `csv:"name"`
+	Description string `csv:"description"`
+	AvatarUrl   string `csv:"avatar_url"`
+	// Degree of connection with author, 1 for 1st, 2 for 2nd and 3 for 3rd+, zero when unknown
+	Degree    int       `csv:"degree"`
+	Followers int       `csv:"followers"`
+	Premium   bool      `csv:"premium"`
+	Verified  bool      `csv:"verified"`
+	CreatedAt time.Time `csv:"-"`
+	UpdatedAt time.Time `csv:"-"`
+}
+
+const (
+	RelationFollowing         = "following"
+	RelationInvitationPending = "invitation_pending"
+)
+
+type Relationship struct {
+	AuthorId  uint64    `csv:"author_id"`
+	Relation  string    `csv:"relation"`
+	Mutuals   bool      `csv:"mutuals"`
+	UpdatedAt time.Time `csv:"-"`
+}
+
+type Content struct {
+	Post   Post   `csv:"post"`
+	Author Author `csv:"author"`
+}
+
+const (
+	MediaImage    = "image"
+	MediaVideo    = "video"
+	MediaDocument = "document"
+	MediaPoll     = "poll"
+	MediaLink     = "link"
+)
+
+// Media is something attached to a post
+type Media struct {
+	ID     uint64 `csv:"-"`
+	PostId uint64 `csv:"post_id"`
+	Type   string `csv:"type"`
+	// Url is the image, video, document or link preview address, empty for polls
+	Url string `csv:"url"`
+	// Title of link preview or document
+	Title string `csv:"title"`
+	// Domain of link preview
+	Domain string `csv:"domain"`
+	// Options of poll
+	Options List `csv:"options"`
+	// Path where media was downloaded, empty when not downloaded
+	Path      string    `csv:"path"`
+	CreatedAt time.Time `csv:"-"`
+}
+
+// Downloadable check if media is a file worth to save, like images and documents,
+// documents without pdf address only have the linkedin viewer page and are not saved
+func (m Media) Downloadable() bool {
+	switch m.Type {
+	case MediaImage:
+		return m.Url != ""
+	case MediaDocument:
+		parsed, err := url.Parse(m.Url)
+		return m.Url != "" && err == nil && parsed.Hostname() != "linkedin.com" && !strings.HasSuffix(parsed.Hostname(), ".linkedin.com")
+	}
+	return false
+}
+
+type Comment struct {
 	ID        uint64    `csv:"-"`
-	Url       string    `csv:"url"`
+	PostId    uint64    `csv:"post_id"`
 	Content   string    `csv:"content"`
-	AuthorUrl string    `csv:"author_url"`
-	AuthorId  uint64    `csv:"-"`
+	CreatedAt time.Time `csv:"created_at"`
+}
+
+const (
+	ProspectByMessage = "message"
+	ProspectByConnect = "connect"
+)
+
+type Prospect struct {
+	ID        uint64    `csv:"-"`
+	AuthorId  uint64    `csv:"author_id"`
+	PostId    uint64    `csv:"post_id"`
+	Channel   string    `csv:"channel"`
+	Content   string    `csv:"content"`
+	CreatedAt time.Time `csv:"created_at"`
+}
+
+type Job struct {
+	ID        uint64    `csv:"-"`
+	JobId     string    `csv:"job_id"`
+	Url       string    `csv:"url"`
+	Title     string    `csv:"title"`
+	Company   string    `csv:"company"`
+	Location  string    `csv:"location"`
 	CreatedAt time.Time `csv:"-"`
 	UpdatedAt time.Time `csv:"-"`
 }
 
-type Author struct {
+type Company struct {
 	ID          uint64    `csv:"-"`
 	Url         string    `csv:"url"`
 	Name        string    `csv:"name"`
 	Description string    `csv:"description"`
+	Followers   string    `csv:"followers"`
 	CreatedAt   time.Time `csv:"-"`
 	UpdatedAt   time.Time `csv:"-"`
 }
 
-type Relationship struct {
-	AuthorId uint64 `csv:"author_id"`
-	Relation string `csv:"relation"`
-	Mutuals  bool   `csv:"mutuals"`
+// Quota is the usage of a mutating action against his daily and weekly limits,
+// a zero limit means no limit
+type Quota struct {
+	Action    string `csv:"action"`
+	Daily     int    `csv:"daily"`
+	Weekly    int    `csv:"weekly"`
+	UsedToday int    `csv:"used_today"`
+	UsedWeek  int    `csv:"used_week"`
 }
 
-type Content struct {
-	Post   Post   `csv:"post"`
-	Author Author `csv:"author"`
+// Remaining returns how many actions still can be done, -1 when there is no limit
+func (q Quota) Remaining() int {
+	remaining := -1
+	if q.Daily > 0 {
+		remaining = max(q.Daily-q.UsedToday, 0)
+	}
+	if q.Weekly > 0 {
+		weekRemaining := max(q.Weekly-q.UsedWeek, 0)
+		if remaining < 0 || weekRemaining < remaining {
+			remaining = weekRemaining
+		}
+	}
+	return remaining
+}
+
+func (q Quota) Exceeded() bool {
+	return q.Remaining() == 0
 }
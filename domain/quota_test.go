@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+func TestQuotaRemaining(t *testing.T) {
+	cases := []struct {
+		quota     domain.Quota
+		remaining int
+	}{
+		{domain.Quota{}, -1},
+		{domain.Quota{Daily: 10, UsedToday: 3}, 7},
+		{domain.Quota{Weekly: 50, UsedWeek: 48, Daily: 10, UsedToday: 1}, 2},
+		{domain.Quota{Daily: 10, UsedToday: 12}, 0},
+	}
+	for _, c := range cases {
+		if res := c.quota.Remaining(); res != c.remaining {
+			t.Fatalf("expect %d remaining for %v, got %d", c.remaining, c.quota, res)
+		}
+	}
+
+	if !(domain.Quota{Daily: 5, UsedToday: 5}).Exceeded() {
+		t.Fatalf("quota should be exceeded")
+	}
+	if (domain.Quota{}).Exceeded() {
+		t.Fatalf("quota without limits should not be exceeded")
+	}
+}
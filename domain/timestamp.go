@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Timestamp is an optional time, stored as NULL and exported as empty text in csv when zero
+type Timestamp struct {
+	time.Time
+}
+
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t.UTC()}
+}
+
+func (t Timestamp) MarshalCSV() (string, error) {
+	if t.IsZero() {
+		return "", nil
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+func (t *Timestamp) UnmarshalCSV(text string) error {
+	if text == "" {
+		*t = Timestamp{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return err
+	}
+	*t = NewTimestamp(parsed)
+	return nil
+}
+
+func (t Timestamp) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.UTC(), nil
+}
+
+func (t *Timestamp) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*t = Timestamp{}
+	case time.Time:
+		*t = NewTimestamp(value)
+	default:
+		return fmt.Errorf("can not scan %T into timestamp", src)
+	}
+	return nil
+}
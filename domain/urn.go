@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+const (
+	postPermalinkPrefix = "https://www.linkedin.com/feed/update/"
+
+	UrnActivity = "activity"
+	UrnUgcPost  = "ugcPost"
+	UrnShare    = "share"
+)
+
+var urnPattern = regexp.MustCompile(`urn:li:(activity|ugcPost|share):(\d+)`)
+
+// URN identify a linkedin post, like urn:li:activity:7151313167762010113
+type URN string
+
+// ParseURN extract the post urn from a raw urn or a linkedin post url
+func ParseURN(ref string) (URN, error) {
+	if unescaped, err := url.QueryUnescape(ref); err == nil {
+		ref = unescaped
+	}
+	urn := urnPattern.FindString(ref)
+	if urn == "" {
+		return "", fmt.Errorf("invalid post urn or url, got %v, expected urn:li:activity, urn:li:ugcPost or urn:li:share", ref)
+	}
+	return URN(urn), nil
+}
+
+// Kind returns activity, ugcPost or share
+func (u URN) Kind() string {
+	if match := urnPattern.FindStringSubmatch(string(u)); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Id returns the numeric part of urn
+func (u URN) Id() string {
+	if match := urnPattern.FindStringSubmatch(string(u)); match != nil {
+		return match[2]
+	}
+	return ""
+}
+
+// Valid check if urn is exactly a post urn, without anything around it
+func (u URN) Valid() bool {
+	return u != "" && urnPattern.FindString(string(u)) == string(u)
+}
+
+func (u URN) String() string {
+	return string(u)
+}
+
+// Permalink build the public url of post
+func (u URN) Permalink() string {
+	return postPermalinkPrefix + string(u) + "/"
+}
+
+func (u URN) Value() (driver.Value, error) {
+	return string(u), nil
+}
+
+func (u *URN) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*u = ""
+	case string:
+		*u = URN(value)
+	case []byte:
+		*u = URN(value)
+	default:
+		return fmt.Errorf("can not scan %T into urn", src)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+func TestParseURN(t *testing.T) {
+	cases := map[string]string{
+		"urn:li:activity:7151313167762010113":                                           "urn:li:activity:7151313167762010113",
+		"https://www.linkedin.com/feed/update/urn:li:activity:7151313167762010113/":     "urn:li:activity:7151313167762010113",
+		"https://www.linkedin.com/feed/update/urn%3Ali%3AugcPost%3A7151313167762010113": "urn:li:ugcPost:7151313167762010113",
+	}
+	for ref, expected := range cases {
+		urn, err := domain.ParseURN(ref)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if urn.String() != expected {
+			t.Fatalf("expect %s, got %s", expected, urn)
+		}
+	}
+
+	for _, ref := range []string{"https://www.linkedin.com/in/someone", "urn:li:fs_miniProfile:1", "urn:li:activity:"} {
+		if _, err := domain.ParseURN(ref); err == nil {
+			t.Fatalf("%s should not be a post urn", ref)
+		}
+	}
+}
+
+func TestURN(t *testing.T) {
+	urn := domain.URN("urn:li:share:1")
+	if urn.Kind() != domain.UrnShare || urn.Id() != "1" {
+		t.Fatalf("unexpected kind %s and id %s", urn.Kind(), urn.Id())
+	}
+	if res := urn.Permalink(); res != "https://www.linkedin.com/feed/update/urn:li:share:1/" {
+		t.Fatalf("unexpected permalink %s", res)
+	}
+	if domain.URN("urn:li:activity:abc").Valid() {
+		t.Fatalf("urn without numeric id should be invalid")
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/gocarina/gocsv"
+	"github.com/spf13/cobra"
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+// followUser is a function to using id from database or url to follow a linkedin user,
+// or a batch of users from database filters or csv file
+// this function handle for follow-user command
+func followUser(cmd *cobra.Command, args []string) error {
+	selectedAction, err := cmd.Flags().GetString(flagAction)
+	if err != nil {
+
+		return fmt.Errorf("failed to get action flag: %w", err)
+	}
+
+	users, err := followTargets(cmd)
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		fmt.Println("no authors to follow")
+		return nil
+	}
+
+	credentials, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := startBrowser(credentials)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := workflow.Run(ctx,
+		workflow.Login(credentials.Username, credentials.Password, challengeResolver(credentials)),
+	); err != nil {
+		return fmt.Errorf("failed to execute chromedp tasks: %w", err)
+	}
+
+	if len(users) == 1 {
+		return followAuthor(ctx, users[0], selectedAction)
+	}
+
+	failures := 0
+	for i, user := range users {
+		err := followAuthor(ctx, user, selectedAction)
+		if errors.Is(err, errQuotaExceeded) {
+			failures += len(users) - i
+			fmt.Printf("stopped at %s: %v\n", user.Url, err)
+			break
+		}
+		if err != nil {
+			failures++
+			fmt.Printf("failed %s: %v\n", user.Url, err)
+			continue
+		}
+		fmt.Printf("done %s\n", user.Url)
+	}
+	fmt.Printf("%s executed for %d of %d authors\n", selectedAction, len(users)-failures, len(users))
+	if failures > 0 {
+		return fmt.Errorf("failed to %s %d of %d authors", selectedAction, failures, len(users))
+	}
+
+	return nil
+}
+
+// followTargets get authors to follow from id, url, database filters or csv file flags
+func followTargets(cmd *cobra.Command) ([]domain.Author, error) {
+	url, err := cmd.Flags().GetString(flagUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get url flag: %w", err)
+	}
+
+	userId, err := cmd.Flags().GetInt(flagId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get id flag: %w", err)
+	}
+
+	fromDb, err := cmd.Flags().GetBool(flagFromDb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from db flag: %w", err)
+	}
+
+	fromFile, err := cmd.Flags().GetString(flagFromFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from file flag: %w", err)
+	}
+
+	limit, err := cmd.Flags().GetInt(flagLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limit flag: %w", err)
+	}
+
+	switch {
+	case fromDb:
+		var filter storage.AuthorFilter
+		if filter.PostKeyword, err = cmd.Flags().GetString(flagKeyword); err != nil {
+			return nil, fmt.Errorf("failed to get keyword flag: %w", err)
+		}
+		if filter.NotFollowed, err = cmd.Flags().GetBool(flagNotFollowed); err != nil {
+			return nil, fmt.Errorf("failed to get not followed flag: %w", err)
+		}
+		if filter.PostedSince, err = readPostedSince(cmd); err != nil {
+			return nil, err
+		}
+		if filter.Degree, err = cmd.Flags().GetInt(flagDegree); err != nil {
+			return nil, fmt.Errorf("failed to get degree flag: %w", err)
+		}
+		filter.Limit = limit
+		return authorStore.List(filter)
+	case fromFile != "":
+		separator, err := cmd.Flags().GetString(flagSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get csv separator: %w", err)
+		}
+		if separator == "" {
+			return nil, errors.New("csv separator can not be empty")
+		}
+		authors, err := readAuthorsFile(fromFile, separator)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(authors) > limit {
+			authors = authors[:limit]
+		}
+		return authors, nil
+	case url == "" && userId == 0:
+		return nil, fmt.Errorf("neither url, id, from-db or from-file is availabe")
+	}
+
+	user, err := resolveAuthor(userId, url)
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Author{*user}, nil
+}
+
+// followAuthor open author profile and execute follow action, recording when user is followed,
+// Connect send a connection request without note like connect command
+func followAuthor(ctx context.Context, user domain.Author, selectedAction string) error {
+	quotaAction, limited := followQuota(selectedAction)
+	if limited {
+		if err := checkQuota(quotaAction); err != nil {
+			return err
+		}
+	}
+	if err := workflow.Run(ctx, workflow.GoToUserPage(user)); err != nil {
+		return fmt.Errorf("failed to execute chromedp tasks: %w", err)
+	}
+	if selectedAction == actionConnect {
+		if err := workflow.SendConnectionRequest(ctx, ""); err != nil {
+			return err
+		}
+	} else if err := workflow.ExecuteFollowAction(ctx, selectedAction); err != nil {
+		return err
+	}
+	if limited {
+		consumeQuota(quotaAction)
+	}
+
+	var relation string
+	switch selectedAction {
+	case actionFollow:
+		relation = domain.RelationFollowing
+	case actionConnect:
+		relation = domain.RelationInvitationPending
+	default:
+		return nil
+	}
+	_, err := relationStore.Upsert(&domain.Relationship{AuthorId: user.ID, Relation: relation})
+	return err
+}
+
+// followQuota get the quota counting the profile action, Unfollow and other buttons are not limited
+func followQuota(selectedAction string) (workflow.Action, bool) {
+	switch selectedAction {
+	case actionFollow:
+		return workflow.ActionFollow, true
+	case actionConnect:
+		return workflow.ActionConnect, true
+	}
+	return "", false
+}
+
+// readAuthorsFile read authors from csv written by search -o, both posts and people exports are supported
+func readAuthorsFile(filePath, separator string) ([]domain.Author, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	newReader := func() *csv.Reader {
+		csvReader := csv.NewReader(bytes.NewReader(content))
+		csvReader.Comma = []rune(separator)[0]
+		csvReader.LazyQuotes = true
+		return csvReader
+	}
+
+	header, err := newReader().Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	var authors []domain.Author
+	if slices.Contains(header, "author.url") {
+		var contents []domain.Content
+		if err := gocsv.UnmarshalCSV(newReader(), &contents); err != nil {
+			return nil, err
+		}
+		for _, v := range contents {
+			authors = append(authors, v.Author)
+		}
+	} else if err := gocsv.UnmarshalCSV(newReader(), &authors); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Author, 0, len(authors))
+	for _, v := range authors {
+		if v.Url == "" {
+			continue
+		}
+		author, err := authorStore.GetByUrl(v.Url)
+		if errors.Is(err, sql.ErrNoRows) {
+			author, err = authorStore.Upsert(&v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *author)
+	}
+	return result, nil
+}
+
+// connectUser is a function to using id from database or url to send a connection request
+// with optional note template to a linkedin user, this function handle for connect command
+func connectUser(cmd *cobra.Command, args []string) error {
+	url, err := cmd.Flags().GetString(flagUrl)
+	if err != nil {
+		return fmt.Errorf("failed to get url flag: %w", err)
+	}
+
+	userId, err := cmd.Flags().GetInt(flagId)
+	if err != nil {
+		return fmt.Errorf("failed to get id flag: %w", err)
+	}
+	if url == "" && userId == 0 {
+		return fmt.Errorf("neither url or id is availabe")
+	}
+
+	text, err := readText(cmd)
+	if err != nil {
+		return err
+	}
+
+	user, err := resolveAuthor(userId, url)
+	if err != nil {
+		return err
+	}
+	note, err := domain.RenderMessage(text, domain.Content{Author: *user})
+	if err != nil {
+		return err
+	}
+	if err := domain.ValidateConnectionNote(note); err != nil {
+		return err
+	}
+	if err := checkQuota(workflow.ActionConnect); err != nil {
+		return err
+	}
+
+	credentials, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := startBrowser(credentials)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := workflow.Run(ctx,
+		workflow.Login(credentials.Username, credentials.Password, challengeResolver(credentials)), workflow.GoToUserPage(*user),
+	); err != nil {
+		return fmt.Errorf("failed to execute chromedp tasks: %w", err)
+	}
+	if err := workflow.SendConnectionRequest(ctx, note); err != nil {
+		return err
+	}
+	consumeQuota(workflow.ActionConnect)
+	if _, err := relationStore.Upsert(&domain.Relationship{AuthorId: user.ID, Relation: domain.RelationInvitationPending}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resolveAuthor find author by id, or by url storing it when is unknown
+func resolveAuthor(id int, url string) (*domain.Author, error) {
+	if id != 0 {
+		return authorStore.GetById(uint64(id))
+	}
+	author, err := authorStore.GetByUrl(url)
+	if errors.Is(err, sql.ErrNoRows) {
+		return authorStore.Upsert(&domain.Author{Url: url})
+	}
+	return author, err
+}
@@ -4,21 +4,22 @@ import (
 	"bufio"
 	"context"
 	"database/sql"
-	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/chromedp/chromedp"
-	"github.com/gocarina/gocsv"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
-	"github.com/victorfernandesraton/lazydin/adapters"
 	"github.com/victorfernandesraton/lazydin/browser"
 	"github.com/victorfernandesraton/lazydin/config"
 	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/otp"
+	"github.com/victorfernandesraton/lazydin/selectors"
 	"github.com/victorfernandesraton/lazydin/storage"
 	"github.com/victorfernandesraton/lazydin/workflow"
 )
@@ -37,6 +38,39 @@ const (
 	flagUrl                = "url"
 	flagId                 = "id"
 	flagAction             = "action"
+	flagText               = "text"
+	flagFile               = "file"
+	flagChannel            = "channel"
+	flagLimit              = "limit"
+	flagFilter             = "filter"
+	flagMaxPages           = "max-pages"
+	flagDatePosted         = "date-posted"
+	flagSort               = "sort"
+	flagContentType        = "content-type"
+	flagFromMember         = "from-member"
+	flagAuthorCompany      = "author-company"
+	flagFromDb             = "from-db"
+	flagFromFile           = "from-file"
+	flagKeyword            = "keyword"
+	flagNotFollowed        = "not-followed"
+	flagBaseUrl            = "base-url"
+	flagHtml               = "html"
+	flagHeadless           = "headless"
+	flagWindowSize         = "window-size"
+	flagChromePath         = "chrome-path"
+	flagUserAgent          = "user-agent"
+	flagProxy              = "proxy"
+	flagLocale             = "locale"
+	flagChromeFlag         = "chrome-flag"
+	flagRemoteBrowser      = "remote-browser"
+	flagLive               = "live"
+	flagPostedWithin       = "posted-within"
+	flagDegree             = "degree"
+	flagDownloadMedia      = "download-media"
+	mediaDir               = "media"
+	channelAuto            = "auto"
+	actionFollow           = "Follow"
+	actionConnect          = "Connect"
 	defaultDatabaseFile    = "lazydin.sqlite"
 	defaultCredentialsFile = "credentials.toml"
 	configUsername         = "username"
@@ -50,38 +84,61 @@ var (
 	databse         *sql.DB
 	postsStore      *storage.PostStorage
 	authorStore     *storage.AuthorStorage
+	commentStore    *storage.CommentStorage
+	prospectStore   *storage.ProspectStorage
+	relationStore   *storage.RelationshipStorage
+	jobStore        *storage.JobStorage
+	companyStore    *storage.CompanyStorage
+	quotaStore      *storage.QuotaStorage
+	mediaStore      *storage.MediaStorage
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "lazydin",
 	Short: "CLI for interacting with Linkedin",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		baseUrl := configs.Linkedin.BaseUrl
+		if flagValue, _ := cmd.Flags().GetString(flagBaseUrl); flagValue != "" {
+			baseUrl = flagValue
+		}
+		if baseUrl != "" {
+			workflow.SetBaseUrl(baseUrl)
+		}
+	},
 }
 
 var commands = []cobra.Command{
 	{
 		Use:   "search",
-		Short: "Search for posts on Linkedin",
+		Short: "Search for posts on Linkedin, use subcommands for people, jobs and companies",
 		RunE:  searchPosts,
 	}, {
 		Use:     "follow",
 		Short:   "Follow specific user By id or url",
-		Example: "follow [--id integer | --url user linkedin profile urls]",
+		Example: "follow [--id integer | --url user linkedin profile urls | --from-db [--keyword golang] [--not-followed] | --from-file authors.csv]",
 		RunE:    followUser,
 	},
 	{
-		Use:   "prospect",
-		Short: "UNDER CONSTRUCTION Prospect about some post/job with the author",
-		Run: func(cmd *cobra.Command, args []string) {
-			log.Fatal(errors.New("not implemented yet"))
-		},
+		Use:     "prospect",
+		Short:   "Prospect about some post/job with the author",
+		Example: "prospect --text \"Hi {{.Author.Name}}, about {{.Post.Url}}\" [--channel auto|message|connect] [--filter golang] [--limit 10]",
+		RunE:    prospectAuthors,
 	},
 
 	{
-		Use:   "comment",
-		Short: "Post a comment on a Linkedin post",
-		Run: func(cmd *cobra.Command, args []string) {
-			log.Fatal(errors.New("not implemented yet"))
-		},
+		Use:     "comment",
+		Short:   "Post a comment on a Linkedin post",
+		Example: "comment [--id integer | --url post urn or url] [--text comment | --file path | - for stdin]",
+		RunE:    commentOnPost,
+	},
+	{
+		Use:   "session",
+		Short: "Manage stored browser session",
+	},
+	{
+		Use:   "logout",
+		Short: "Remove stored browser session for current user",
+		RunE:  clearSession,
 	},
 	{
 		Use:   "create-credentials",
@@ -96,7 +153,11 @@ var commands = []cobra.Command{
 			fmt.Print("Enter password: ")
 			password, _ := reader.ReadString('\n')
 			password = strings.TrimSpace(password)
-			return config.SetCredentials(username, password)
+
+			fmt.Print("Enter authenticator secret for 2FA (optional): ")
+			totpSecret, _ := reader.ReadString('\n')
+			totpSecret = strings.TrimSpace(totpSecret)
+			return config.SetCredentials(username, password, totpSecret)
 
 		},
 	},
@@ -112,6 +173,24 @@ var commands = []cobra.Command{
 
 		},
 	},
+	{
+		Use:     "connect",
+		Short:   "Send connection request to specific user By id or url",
+		Example: "connect [--id integer | --url user linkedin profile urls] [--text \"Hi {{.Author.Name}}\" | --file path]",
+		RunE:    connectUser,
+	},
+	{
+		Use:   "quota",
+		Short: "Manage daily and weekly limits of follows, connections, comments and messages",
+	},
+	{
+		Use:     "doctor",
+		Short:   "Check chrome, config, storage, credentials and selectors before a real run",
+		Example: "doctor [--html saved-search.html | --live [--query golang]] [selector prefixes like search content]",
+		RunE:    runDoctor,
+		// failed checks are in the report, usage would only hide it
+		SilenceUsage: true,
+	},
 }
 
 func init() {
@@ -119,185 +198,547 @@ func init() {
 	rootCmd.PersistentFlags().StringP(flagUser, "u", "", "Linkedin Username")
 	rootCmd.PersistentFlags().StringP(flagPassword, "p", "", "Linkedin Password")
 	rootCmd.PersistentFlags().String(flagCredentials, credentialsFile, "Credential file storage in toml")
-
-	commands[0].Flags().StringP(flagQuery, "q", "", "Query for search post")
-	commands[0].Flags().StringP(flagOutput, "o", "", "Output file as csv")
-	commands[0].Flags().StringP(flagSeparator, "", ";", "Output file as csv separator")
+	rootCmd.PersistentFlags().String(flagBaseUrl, "", "Linkedin base url, like a local server with saved pages")
+	rootCmd.PersistentFlags().Bool(flagHeadless, false, "Run chrome without window")
+	rootCmd.PersistentFlags().String(flagWindowSize, "", "Chrome window size like 1280x800, default maximized")
+	rootCmd.PersistentFlags().String(flagChromePath, "", "Chrome or chromium executable")
+	rootCmd.PersistentFlags().String(flagUserAgent, "", "Chrome user agent")
+	rootCmd.PersistentFlags().String(flagProxy, "", "Proxy like http://host:3128 or socks5://host:1080")
+	rootCmd.PersistentFlags().String(flagLocale, "", "Chrome language like en-US")
+	rootCmd.PersistentFlags().StringSlice(flagChromeFlag, nil, "Extra chrome flags like disable-gpu or window-position=0,0")
+	rootCmd.PersistentFlags().String(flagRemoteBrowser, "", "Remote debugging url of a running chrome like ws://127.0.0.1:9222")
+
+	addSearchFlags(&commands[0])
+	commands[0].Flags().StringP(flagDatePosted, "", "", "Filter by date posted: past-24h, past-week or past-month")
+	commands[0].Flags().StringP(flagSort, "", "", "Sort results by latest or relevance")
+	commands[0].Flags().StringP(flagContentType, "", "", "Filter by content type: jobs, documents, images or videos")
+	commands[0].Flags().StringSliceP(flagFromMember, "", nil, "Filter by author profile ids (like ACoAAB...)")
+	commands[0].Flags().StringSliceP(flagAuthorCompany, "", nil, "Filter by author company ids")
+	commands[0].Flags().BoolP(flagDownloadMedia, "", false, "Save images and documents of posts in media folder next to database")
+	commands[0].Flags().StringP(flagPostedWithin, "", "", "Keep only posts published within this age, like 24h, 7d, 2w or 1mo")
 
 	commands[1].Flags().StringP(flagUrl, "", "", "valid profile url")
 	commands[1].Flags().IntP(flagId, "", 0, "valid author id")
-	commands[1].Flags().StringP(flagAction, "a", "Follow", "Action to execute")
+	commands[1].Flags().StringP(flagAction, "a", actionFollow, "Profile button to click, Connect send a connection request without note")
+	commands[1].Flags().BoolP(flagFromDb, "", false, "Follow stored authors, filtered by --keyword, --posted-within, --degree and --not-followed")
+	commands[1].Flags().StringP(flagKeyword, "", "", "Only authors with posts containing this text, used with --from-db")
+	commands[1].Flags().BoolP(flagNotFollowed, "", false, "Only authors not followed yet, used with --from-db")
+	commands[1].Flags().IntP(flagDegree, "", 0, "Only authors with this connection degree (1, 2 or 3 for 3rd+), used with --from-db")
+	commands[1].Flags().StringP(flagPostedWithin, "", "", "Only authors with posts published within this age, like 7d, used with --from-db")
+	commands[1].Flags().IntP(flagLimit, "l", 10, "Max authors to follow with --from-db or --from-file, 0 for no limit")
+	commands[1].Flags().StringP(flagFromFile, "", "", "Follow authors from csv written by search -o")
+	commands[1].Flags().StringP(flagSeparator, "", ";", "Separator of csv in --from-file")
+
+	commands[2].Flags().StringP(flagText, "t", "", "Message template, using fields from author and post like {{.Author.Name}}")
+	commands[2].Flags().StringP(flagFile, "f", "", "File with message template, use - for stdin")
+	commands[2].Flags().StringP(flagChannel, "", channelAuto, "How to contact author: auto, message or connect")
+	commands[2].Flags().IntP(flagLimit, "l", 10, "Max authors to contact in this run")
+	commands[2].Flags().StringP(flagFilter, "", "", "Only authors with posts containing this text")
+	commands[2].Flags().StringP(flagPostedWithin, "", "", "Only authors with posts published within this age, like 7d")
+
+	commands[3].Flags().StringP(flagUrl, "", "", "valid post urn or url")
+	commands[3].Flags().IntP(flagId, "", 0, "valid post id")
+	commands[3].Flags().StringP(flagText, "t", "", "Comment text")
+	commands[3].Flags().StringP(flagFile, "f", "", "File with comment text, use - for stdin")
+
+	commands[8].Flags().StringP(flagUrl, "", "", "valid profile url")
+	commands[8].Flags().IntP(flagId, "", 0, "valid author id")
+	commands[8].Flags().StringP(flagText, "t", "", "Note template, using fields from author like {{.Author.Name}}, max 300 characters")
+	commands[8].Flags().StringP(flagFile, "f", "", "File with note template, use - for stdin")
+
+	commands[9].AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show how many actions are left today and in last 7 days",
+		RunE:  quotaStatus,
+	})
+
+	commands[10].Flags().StringP(flagHtml, "", "", "Saved linkedin page to check selectors against")
+	commands[10].Flags().BoolP(flagLive, "", false, "Check selectors against a live search after login")
+	commands[10].Flags().StringP(flagQuery, "q", "golang", "Query of live search")
+
+	for i := range searchVerticals {
+		addSearchFlags(&searchVerticals[i])
+		commands[0].AddCommand(&searchVerticals[i])
+	}
 
-	for _, cmd := range commands {
-		rootCmd.AddCommand(&cmd)
+	commands[4].AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove stored browser session for current user",
+		RunE:  clearSession,
+	})
+
+	for i := range commands {
+		rootCmd.AddCommand(&commands[i])
 	}
 
 }
 
 func main() {
+	if err := setup(); err != nil {
+		log.Fatalf(err.Error())
+	}
+	if err := rootCmd.Execute(); err != nil {
+		log.Fatalf(err.Error())
+	}
+}
+
+// setup load config and selectors, opening the database and creating tables used by commands
+func setup() error {
 	var err error
 
 	configs, err = config.LoadConfig()
 	if err != nil {
-		log.Fatalf(err.Error())
+		return err
+	}
+	workflow.SetPacing(pacingFromConfig(configs.Pacing))
+	workflow.SetArtifactsDir(configs.Artifacts)
+	workflow.SetSteps(stepsFromConfig(configs.Steps))
 
+	selectorsFile, err := config.SelectorsFile()
+	if err != nil {
+		return err
 	}
+	if err := selectors.Load(selectorsFile); err != nil {
+		return err
+	}
+
 	if _, err := os.Stat(configs.SQlite); os.IsNotExist(err) {
 		if _, err := os.Create(configs.SQlite); err != nil {
-			log.Fatalf(err.Error())
+			return err
 		}
 	}
 	databse, err = sql.Open("sqlite3", configs.SQlite)
 	if err != nil {
-		log.Fatalf(err.Error())
+		return err
 	}
 
 	postsStore = storage.NewPostStorage(databse)
 	authorStore = storage.NewAuthorStorage(databse)
-	if err = authorStore.CreateTable(); err != nil {
-		log.Fatalf(err.Error())
+	commentStore = storage.NewCommentStorage(databse)
+	prospectStore = storage.NewProspectStorage(databse)
+	relationStore = storage.NewRelationshipStorage(databse)
+	jobStore = storage.NewJobStorage(databse)
+	companyStore = storage.NewCompanyStorage(databse)
+	quotaStore = storage.NewQuotaStorage(databse)
+	mediaStore = storage.NewMediaStorage(databse)
 
+	if err = authorStore.CreateTable(); err != nil {
+		return err
 	}
 
 	if err = postsStore.CreateTable(); err != nil {
-		log.Fatalf(err.Error())
+		return err
 	}
-	if err = rootCmd.Execute(); err != nil {
-		log.Fatalf(err.Error())
+
+	if err = commentStore.CreateTable(); err != nil {
+		return err
+	}
+
+	if err = prospectStore.CreateTable(); err != nil {
+		return err
+	}
+
+	if err = relationStore.CreateTable(); err != nil {
+		return err
+	}
+
+	if err = jobStore.CreateTable(); err != nil {
+		return err
+	}
+
+	if err = companyStore.CreateTable(); err != nil {
+		return err
+	}
+
+	if err = quotaStore.CreateTable(); err != nil {
+		return err
 	}
+	workflow.SetMutationLog(quotaStore)
+
+	if err = mediaStore.CreateTable(); err != nil {
+		return err
+	}
+	return nil
 }
 
-// searchPosts handles the search-posts command
-func searchPosts(cmd *cobra.Command, args []string) error {
-	query, err := cmd.Flags().GetString(flagQuery)
+// commentOnPost is a function to using id from database or post urn/url to comment on a linkedin post
+// this function handle for comment command
+func commentOnPost(cmd *cobra.Command, args []string) error {
+	var post *domain.Post
+	url, err := cmd.Flags().GetString(flagUrl)
 	if err != nil {
-		return fmt.Errorf("failed to get query flag: %w", err)
+		return fmt.Errorf("failed to get url flag: %w", err)
 	}
-	if query == "" {
-		return errors.New("query flag is required")
+
+	postId, err := cmd.Flags().GetInt(flagId)
+	if err != nil {
+		return fmt.Errorf("failed to get id flag: %w", err)
+	}
+	if url == "" && postId == 0 {
+		return fmt.Errorf("neither url or id is availabe")
 	}
-	outputFile, err := cmd.Flags().GetString(flagOutput)
+
+	text, err := readText(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get output flag: %w", err)
+		return err
+	}
+	if text == "" {
+		return errors.New("comment text is required, use --text or --file, - for stdin")
 	}
 
-	if !strings.HasSuffix(outputFile, ".csv") && outputFile != "" {
-		return fmt.Errorf("invalid file format for output, got %v, but only supported is .csv", outputFile)
+	if postId != 0 {
+		post, err = postsStore.GetById(uint64(postId))
+		if err != nil {
+			return err
+		}
+	} else {
+		urn, err := domain.ParseURN(url)
+		if err != nil {
+			return err
+		}
+		post, err = postsStore.GetByUrn(urn)
+		if errors.Is(err, sql.ErrNoRows) {
+			post, err = postsStore.Upsert(&domain.Post{Urn: urn, Url: urn.Permalink()})
+		}
+		if err != nil {
+			return err
+		}
 	}
 
-	separator, err := cmd.Flags().GetString(flagSeparator)
-	if err != nil {
-		return fmt.Errorf("failed to get csv separator: %w", err)
+	if err := checkQuota(workflow.ActionComment); err != nil {
+		return err
 	}
 
-	usernameFlag := rootCmd.PersistentFlags().Lookup(flagUser).Value.String()
-	passwordFlag := rootCmd.PersistentFlags().Lookup(flagPassword).Value.String()
-	credentials, err := config.LoadCredentials(configs, usernameFlag, passwordFlag)
+	credentials, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := startBrowser(credentials)
 	if err != nil {
 		return err
 	}
-	opts := browser.CreateBrowserOptions(browser.DefaultBrowserOptions())
-	actx, acancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer acancel()
-
-	ctx, cancel := chromedp.NewContext(actx, chromedp.WithLogf(log.Printf))
 	defer cancel()
 
-	if err := chromedp.Run(ctx,
-		workflow.Auth(credentials.Username, credentials.Password), workflow.SearchForPosts(query),
+	if err := workflow.Run(ctx,
+		workflow.Login(credentials.Username, credentials.Password, challengeResolver(credentials)), workflow.CommentOnPost(*post, text),
 	); err != nil {
 		return fmt.Errorf("failed to execute chromedp tasks: %w", err)
 	}
 
-	content, err := workflow.ExtractOuterHTML(ctx)
+	consumeQuota(workflow.ActionComment)
+	if _, err := commentStore.Create(&domain.Comment{PostId: post.ID, Content: text}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// prospectAuthors contact authors from stored posts not contacted yet
+// this function handle for prospect command
+func prospectAuthors(cmd *cobra.Command, args []string) error {
+	text, err := readText(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to extract outer HTML: %w", err)
+		return err
+	}
+	if text == "" {
+		return errors.New("message text is required, use --text or --file, - for stdin")
 	}
 
-	result, err := adapters.ExtractContent(content)
+	channel, err := cmd.Flags().GetString(flagChannel)
 	if err != nil {
-		return fmt.Errorf("failed to extract content: %w", err)
+		return fmt.Errorf("failed to get channel flag: %w", err)
 	}
-	if outputFile == "" {
-		for _, v := range result {
-			if _, err := postsStore.Upsert(&v.Post); err != nil {
-				return err
-			}
-			if _, err := authorStore.Upsert(&v.Author); err != nil {
-				return err
-			}
-		}
-	} else {
-		file, err := os.Create(outputFile)
+	if channel != channelAuto && channel != domain.ProspectByMessage && channel != domain.ProspectByConnect {
+		return fmt.Errorf("invalid channel, got %v, but only supported are %s, %s and %s",
+			channel, channelAuto, domain.ProspectByMessage, domain.ProspectByConnect)
+	}
+
+	limit, err := cmd.Flags().GetInt(flagLimit)
+	if err != nil {
+		return fmt.Errorf("failed to get limit flag: %w", err)
+	}
+	if limit <= 0 {
+		return errors.New("limit must be greater than zero")
+	}
+
+	filter, err := cmd.Flags().GetString(flagFilter)
+	if err != nil {
+		return fmt.Errorf("failed to get filter flag: %w", err)
+	}
+
+	postedSince, err := readPostedSince(cmd)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := prospectStore.ListCandidates(filter, postedSince, limit)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		fmt.Println("no authors to prospect")
+		return nil
+	}
+
+	credentials, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := startBrowser(credentials)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := workflow.Run(ctx,
+		workflow.Login(credentials.Username, credentials.Password, challengeResolver(credentials)),
+	); err != nil {
+		return fmt.Errorf("failed to execute chromedp tasks: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		message, err := domain.RenderMessage(text, candidate)
 		if err != nil {
 			return err
 		}
-		defer file.Close()
-		csvWriter := csv.NewWriter(file)
-		runeSeparator := []rune(separator)
-		csvWriter.Comma = runeSeparator[0]
-		err = gocsv.MarshalCSV(&result, csvWriter)
+		usedChannel, err := contactAuthor(ctx, candidate.Author, channel, message)
+		if errors.Is(err, errQuotaExceeded) {
+			return err
+		}
 		if err != nil {
+			log.Printf("failed to contact %s: %v", candidate.Author.Url, err)
+			continue
+		}
+		if _, err := prospectStore.Create(&domain.Prospect{
+			AuthorId: candidate.Author.ID,
+			PostId:   candidate.Post.ID,
+			Channel:  usedChannel,
+			Content:  message,
+		}); err != nil {
 			return err
 		}
-		defer csvWriter.Flush()
-
+		if usedChannel == domain.ProspectByConnect {
+			if _, err := relationStore.Upsert(&domain.Relationship{
+				AuthorId: candidate.Author.ID,
+				Relation: domain.RelationInvitationPending,
+			}); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("contacted %s by %s\n", candidate.Author.Url, usedChannel)
 	}
 
 	return nil
 }
 
-// followUser is a function to using id from database or url to follow a linkedin user
-// this function handle for follow-user command
-func followUser(cmd *cobra.Command, args []string) error {
-	var user *domain.Author
-	url, err := cmd.Flags().GetString(flagUrl)
-	if err != nil {
-		return fmt.Errorf("failed to get url flag: %w", err)
+// contactAuthor send message or connection request to author, returning the used channel
+func contactAuthor(ctx context.Context, author domain.Author, channel, message string) (string, error) {
+	if err := workflow.Run(ctx, workflow.GoToUserPage(author)); err != nil {
+		return "", err
+	}
+	if channel != domain.ProspectByConnect {
+		err := checkQuota(workflow.ActionMessage)
+		if err == nil {
+			err = workflow.SendMessage(ctx, message)
+			if err == nil {
+				consumeQuota(workflow.ActionMessage)
+				return domain.ProspectByMessage, nil
+			}
+		}
+		// auto mode fall back to connection request when message is unavailable or out of quota
+		if channel == domain.ProspectByMessage ||
+			!(errors.Is(err, workflow.ErrMessageUnavailable) || errors.Is(err, errQuotaExceeded)) {
+			return "", err
+		}
+	}
+	if err := checkQuota(workflow.ActionConnect); err != nil {
+		return "", err
+	}
+	if err := workflow.SendConnectionRequest(ctx, message); err != nil {
+		return "", err
 	}
+	consumeQuota(workflow.ActionConnect)
+	return domain.ProspectByConnect, nil
+}
 
-	userId, err := cmd.Flags().GetInt(flagId)
+// readText get text content from text flag or file flag, where - is stdin
+func readText(cmd *cobra.Command) (string, error) {
+	text, err := cmd.Flags().GetString(flagText)
 	if err != nil {
-		return fmt.Errorf("failed to get id flag: %w", err)
+		return "", fmt.Errorf("failed to get text flag: %w", err)
 	}
-	if url == "" && userId == 0 {
-		return fmt.Errorf("neither url or id is availabe")
+	if text != "" {
+		return strings.TrimSpace(text), nil
 	}
-	selectedAction, err := cmd.Flags().GetString(flagAction)
-	if err != nil {
 
-		return fmt.Errorf("failed to get action flag: %w", err)
+	filePath, err := cmd.Flags().GetString(flagFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file flag: %w", err)
 	}
 
-	if userId != 0 {
-		user, err = authorStore.GetById(uint64(userId))
+	// stdin is read only when asked, under cron or systemd it is empty or never closed
+	var content []byte
+	switch filePath {
+	case "":
+		return "", nil
+	case "-":
+		content, err = io.ReadAll(os.Stdin)
 		if err != nil {
-			return err
+			return "", err
+		}
+	default:
+		content, err = os.ReadFile(filePath)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// readPostedSince convert posted within flag, like 7d, to the oldest time accepted, zero when not set
+func readPostedSince(cmd *cobra.Command) (time.Time, error) {
+	within, err := cmd.Flags().GetString(flagPostedWithin)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get posted within flag: %w", err)
+	}
+	if within == "" {
+		return time.Time{}, nil
+	}
+	age, err := domain.ParseAge(within)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid posted within: %w", err)
+	}
+	return time.Now().Add(-age), nil
+}
+
+// challengeResolver complete login challenges using totp secret from config,
+// or asking the user when running in a terminal
+func challengeResolver(credentials *config.Credentials) workflow.ChallengeResolver {
+	return func(kind workflow.ChallengeKind) (string, error) {
+		if kind == workflow.ChallengeAuthenticator && credentials.TotpSecret != "" {
+			return otp.TOTP(credentials.TotpSecret, time.Now())
+		}
+		stat, err := os.Stdin.Stat()
+		if err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+			return "", errors.New("no terminal available to complete challenge")
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		switch kind {
+		case workflow.ChallengeEmailPin, workflow.ChallengeAuthenticator:
+			fmt.Printf("Enter %s: ", kind)
+			code, err := reader.ReadString('\n')
+			return strings.TrimSpace(code), err
+		default:
+			fmt.Printf("Complete %s in browser window and press enter", kind)
+			_, err := reader.ReadString('\n')
+			return "", err
 		}
-	} else {
-		user = &domain.Author{Url: url}
 	}
+}
+
+// pacingFromConfig convert pacing section of config to workflow pacing
+func pacingFromConfig(pacing map[string]config.DelayConfig) workflow.Pacing {
+	result := make(workflow.Pacing, len(pacing))
+	for action, delay := range pacing {
+		result[workflow.Action(action)] = workflow.Delay{Min: delay.Min, Max: delay.Max}
+	}
+	return result
+}
+
+func stepsFromConfig(steps config.StepsConfig) workflow.Steps {
+	result := workflow.Steps{
+		Timeouts: make(map[workflow.StepKind]time.Duration, len(steps.Timeout)),
+		Retries:  steps.Retries,
+		Backoff:  steps.Backoff,
+	}
+	for kind, timeout := range steps.Timeout {
+		result.Timeouts[workflow.StepKind(kind)] = timeout
+	}
+	return result
+}
+
+// loadCredentials loads credentials from root flags with config as fallback
+func loadCredentials() (*config.Credentials, error) {
 	usernameFlag := rootCmd.PersistentFlags().Lookup(flagUser).Value.String()
 	passwordFlag := rootCmd.PersistentFlags().Lookup(flagPassword).Value.String()
-	credentials, err := config.LoadCredentials(configs, usernameFlag, passwordFlag)
-	if err != nil {
-		return err
+	return config.LoadCredentials(configs, usernameFlag, passwordFlag)
+}
+
+// loadBrowserOptions read browser section of config, overridden by browser flags
+func loadBrowserOptions() (browser.BrowserOptions, error) {
+	browserConfig := configs.Browser
+	flags := rootCmd.PersistentFlags()
+	var err error
+	if flags.Changed(flagHeadless) {
+		if browserConfig.Headless, err = flags.GetBool(flagHeadless); err != nil {
+			return browser.BrowserOptions{}, fmt.Errorf("failed to get headless flag: %w", err)
+		}
+	}
+	stringFlags := map[string]*string{
+		flagWindowSize:    &browserConfig.WindowSize,
+		flagChromePath:    &browserConfig.ExecPath,
+		flagUserAgent:     &browserConfig.UserAgent,
+		flagProxy:         &browserConfig.Proxy,
+		flagLocale:        &browserConfig.Locale,
+		flagRemoteBrowser: &browserConfig.RemoteUrl,
+	}
+	for name, value := range stringFlags {
+		if flags.Changed(name) {
+			if *value, err = flags.GetString(name); err != nil {
+				return browser.BrowserOptions{}, fmt.Errorf("failed to get %s flag: %w", name, err)
+			}
+		}
+	}
+	if flags.Changed(flagChromeFlag) {
+		chromeFlags, err := flags.GetStringSlice(flagChromeFlag)
+		if err != nil {
+			return browser.BrowserOptions{}, fmt.Errorf("failed to get chrome flag: %w", err)
+		}
+		browserConfig.Flags = append(browserConfig.Flags, chromeFlags...)
 	}
-	opts := browser.CreateBrowserOptions(browser.DefaultBrowserOptions())
-	actx, acancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer acancel()
 
-	ctx, cancel := chromedp.NewContext(actx, chromedp.WithLogf(log.Printf))
-	defer cancel()
+	options := browser.DefaultBrowserOptions()
+	if options.WindowWidth, options.WindowHeight, err = browser.ParseWindowSize(browserConfig.WindowSize); err != nil {
+		return options, err
+	}
+	options.Headless = browserConfig.Headless
+	options.ExecPath = browserConfig.ExecPath
+	options.UserAgent = browserConfig.UserAgent
+	options.Proxy = browserConfig.Proxy
+	options.Locale = browserConfig.Locale
+	options.ExtraFlags = browserConfig.Flags
+	options.RemoteUrl = browserConfig.RemoteUrl
+	return options, nil
+}
 
-	if err := chromedp.Run(ctx,
-		workflow.Auth(credentials.Username, credentials.Password), workflow.GoToUserPage(*user),
-	); err != nil {
-		return fmt.Errorf("failed to execute chromedp tasks: %w", err)
+// startBrowser creates the chrome allocator and the chromedp context used by commands,
+// keeping the browser session of the account between runs
+func startBrowser(credentials *config.Credentials) (context.Context, context.CancelFunc, error) {
+	sessionDir, err := config.SessionDir(credentials.Username)
+	if err != nil {
+		return nil, nil, err
 	}
-	if err := workflow.ExecuteFollowAction(ctx, selectedAction); err != nil {
-		return err
+	browserOptions, err := loadBrowserOptions()
+	if err != nil {
+		return nil, nil, err
 	}
+	browserOptions.UserDataDir = sessionDir
+	ctx, cancel := browser.NewContext(browserOptions, chromedp.WithLogf(log.Printf))
+	return workflow.WatchConsole(ctx), cancel, nil
+}
 
+// clearSession remove stored browser session for current user
+// this function handle for session clear and logout commands
+func clearSession(cmd *cobra.Command, args []string) error {
+	username := rootCmd.PersistentFlags().Lookup(flagUser).Value.String()
+	if username == "" {
+		username = configs.Credentials.Username
+	}
+	if err := config.ClearSession(username); err != nil {
+		return err
+	}
+	fmt.Printf("session cleared for %s\n", username)
 	return nil
 }
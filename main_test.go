@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/mocklinkedin"
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+// runCommand execute lazydin with args against the mock linkedin, like from command line
+func runCommand(t *testing.T, server *mocklinkedin.Server, args ...string) {
+	t.Helper()
+	rootCmd.SetArgs(append(args, "--base-url", server.URL, "--headless"))
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func TestCommandSearchAndFollow(t *testing.T) {
+	found := false
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "headless-shell"} {
+		if _, err := exec.LookPath(name); err == nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Skip("chrome not found, skipping command test")
+	}
+
+	post, err := os.ReadFile(filepath.Join("testdata", "output.html"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	server := mocklinkedin.New(string(post))
+	t.Cleanup(server.Close)
+
+	// config, database and browser session are created in a temporary config dir
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := setup(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	workflow.SetPacing(workflow.Pacing{})
+	t.Cleanup(func() {
+		databse.Close()
+		workflow.SetBaseUrl(workflow.DefaultBaseUrl)
+	})
+
+	runCommand(t, server, "search", "--query", "golang", "--max-pages", "1")
+	if !server.HasAction(mocklinkedin.ActionSearch, "golang") {
+		t.Fatalf("expect search in base url, got %v", server.Actions())
+	}
+	stored, err := postsStore.GetByUrn(domain.URN("urn:li:activity:7151313167762010113"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	author, err := authorStore.GetById(stored.AuthorId)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("follow stored author in base url", func(t *testing.T) {
+		runCommand(t, server, "follow", "--url", author.Url)
+		relation, err := relationStore.GetByAuthor(author.ID)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if relation.Relation != domain.RelationFollowing {
+			t.Fatalf("expect author followed, got %s", relation.Relation)
+		}
+	})
+}
+
+func TestReadText(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "comment.txt")
+	if err := os.WriteFile(file, []byte("  some comment\n"), 0644); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	cases := map[string]struct {
+		args     []string
+		expected string
+	}{
+		"text flag":            {[]string{"--text", " hello "}, "hello"},
+		"file flag":            {[]string{"--file", file}, "some comment"},
+		"without text or file": {nil, ""},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String(flagText, "", "")
+			cmd.Flags().String(flagFile, "", "")
+			if err := cmd.ParseFlags(c.args); err != nil {
+				t.Fatalf(err.Error())
+			}
+			// stdin is a pipe never closed, like under cron, so reading it would block
+			reader, writer, err := os.Pipe()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			defer reader.Close()
+			defer writer.Close()
+			stdin := os.Stdin
+			os.Stdin = reader
+			defer func() { os.Stdin = stdin }()
+
+			text, err := readText(cmd)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if text != c.expected {
+				t.Fatalf("expect %q, got %q", c.expected, text)
+			}
+		})
+	}
+}
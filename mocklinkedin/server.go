@@ -0,0 +1,160 @@
+// Package mocklinkedin serves fake linkedin pages matching the selectors used by
+// workflow, so commands can run end-to-end without touching the real site
+package mocklinkedin
+
+import (
+	"embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	sessionCookie = "li_at"
+	sessionValue  = "mock-session"
+
+	ActionLogin   = "login"
+	ActionSearch  = "search"
+	ActionSeeMore = "see_more"
+)
+
+//go:embed pages/*.html
+var pages embed.FS
+
+var templates = template.Must(template.ParseFS(pages, "pages/*.html"))
+
+// DefaultProfileActions are the buttons shown in profile pages
+var DefaultProfileActions = []string{"Follow", "Message", "More"}
+
+// Action is something done in mock site, like login, search or a profile button click
+type Action struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+// Server is a httptest server with fake linkedin pages
+type Server struct {
+	*httptest.Server
+
+	// ProfileActions are the buttons shown in profile pages, default to DefaultProfileActions
+	ProfileActions []string
+
+	mu      sync.Mutex
+	results []string
+	actions []Action
+}
+
+// New start a mock linkedin server, results are the html of each search result item,
+// like the content of testdata/output.html
+func New(results ...string) *Server {
+	s := &Server{results: results, ProfileActions: DefaultProfileActions}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.home)
+	mux.HandleFunc("/login", s.login)
+	mux.HandleFunc("/feed", s.authenticated(s.feed))
+	mux.HandleFunc("/search/results/", s.authenticated(s.search))
+	mux.HandleFunc("/in/", s.authenticated(s.profile))
+	mux.HandleFunc("/actions", s.recordAction)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Actions returns everything done in mock site until now
+func (s *Server) Actions() []Action {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Action(nil), s.actions...)
+}
+
+// HasAction check if some action with name and target was done
+func (s *Server) HasAction(name, target string) bool {
+	for _, action := range s.Actions() {
+		if action.Name == name && action.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) record(action Action) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions = append(s.actions, action)
+}
+
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookie)
+		if err != nil || cookie.Value != sessionValue {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) home(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	http.Redirect(w, r, "/feed", http.StatusFound)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		render(w, "login.html", nil)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.record(Action{Name: ActionLogin, Target: r.PostForm.Get("session_key")})
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: sessionValue, Path: "/"})
+	http.Redirect(w, r, "/feed", http.StatusFound)
+}
+
+func (s *Server) feed(w http.ResponseWriter, r *http.Request) {
+	render(w, "feed.html", nil)
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("keywords")
+	s.record(Action{Name: ActionSearch, Target: query})
+
+	results := make([]template.HTML, 0, len(s.results))
+	for _, result := range s.results {
+		results = append(results, template.HTML(result))
+	}
+	render(w, "search.html", map[string]any{"Query": query, "Results": results})
+}
+
+func (s *Server) profile(w http.ResponseWriter, r *http.Request) {
+	slug := strings.Trim(strings.TrimPrefix(r.URL.Path, "/in/"), "/")
+	render(w, "profile.html", map[string]any{"Slug": slug, "Actions": s.ProfileActions})
+}
+
+func (s *Server) recordAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var action Action
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.record(action)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,101 @@
+package mocklinkedin_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/mocklinkedin"
+)
+
+func get(t *testing.T, client *http.Client, target string) (*http.Response, string) {
+	response, err := client.Get(target)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return response, string(body)
+}
+
+func TestServer(t *testing.T) {
+	post, err := os.ReadFile(filepath.Join("..", "testdata", "output.html"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	server := mocklinkedin.New(string(post))
+	defer server.Close()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	client := &http.Client{Jar: jar}
+
+	t.Run("redirect to login without session", func(t *testing.T) {
+		response, body := get(t, client, server.URL+"/feed")
+		if response.Request.URL.Path != "/login" {
+			t.Fatalf("expect redirect to /login, got %s", response.Request.URL.Path)
+		}
+		if !strings.Contains(body, `id="username"`) || !strings.Contains(body, `id="password"`) {
+			t.Fatalf("expect login form, got %s", body)
+		}
+	})
+
+	t.Run("login", func(t *testing.T) {
+		response, err := client.PostForm(server.URL+"/login", url.Values{
+			"session_key":      {"user@mail.com"},
+			"session_password": {"secret"},
+		})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		response.Body.Close()
+		if response.Request.URL.Path != "/feed" {
+			t.Fatalf("expect redirect to /feed, got %s", response.Request.URL.Path)
+		}
+		if !server.HasAction(mocklinkedin.ActionLogin, "user@mail.com") {
+			t.Fatalf("expect login recorded, got %v", server.Actions())
+		}
+	})
+
+	t.Run("search results", func(t *testing.T) {
+		_, body := get(t, client, server.URL+"/search/results/content/?keywords=golang")
+		if !strings.Contains(body, "reusable-search__entity-result-list") {
+			t.Fatalf("expect result list, got %s", body)
+		}
+		if !strings.Contains(body, "urn:li:activity:") {
+			t.Fatalf("expect post from testdata in results")
+		}
+		if !server.HasAction(mocklinkedin.ActionSearch, "golang") {
+			t.Fatalf("expect search recorded, got %v", server.Actions())
+		}
+	})
+
+	t.Run("profile actions", func(t *testing.T) {
+		_, body := get(t, client, server.URL+"/in/john-doe/")
+		for _, action := range mocklinkedin.DefaultProfileActions {
+			if !strings.Contains(body, `data-action="`+action+`"`) {
+				t.Fatalf("expect %s button, got %s", action, body)
+			}
+		}
+
+		response, err := client.Post(server.URL+"/actions", "application/json",
+			strings.NewReader(`{"name":"Follow","target":"/in/john-doe/"}`))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		response.Body.Close()
+		if !server.HasAction("Follow", "/in/john-doe/") {
+			t.Fatalf("expect follow recorded, got %v", server.Actions())
+		}
+	})
+}
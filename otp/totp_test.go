@@ -0,0 +1,33 @@
+package otp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/otp"
+)
+
+// secret from RFC 6238 test vectors, "12345678901234567890" encoded as base32
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTP(t *testing.T) {
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expected := range cases {
+		code, err := otp.TOTP(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if code != expected {
+			t.Fatalf("expect %s at %d, got %s", expected, unix, code)
+		}
+	}
+
+	if _, err := otp.TOTP("not base32!", time.Now()); err == nil {
+		t.Fatalf("expected error for invalid secret")
+	}
+}
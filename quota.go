@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+var errQuotaExceeded = errors.New("quota exceeded")
+
+// getQuota returns usage of action with limits from config
+func getQuota(action workflow.Action) (*domain.Quota, error) {
+	limits := configs.Quota[string(action)]
+	return quotaStore.Get(string(action), limits.Daily, limits.Weekly, time.Now())
+}
+
+// checkQuota fail when action already reach his daily or weekly limit
+func checkQuota(action workflow.Action) error {
+	quota, err := getQuota(action)
+	if err != nil {
+		return err
+	}
+	if quota.Exceeded() {
+		return fmt.Errorf("%w for %s: %d today of %d, %d in last 7 days of %d",
+			errQuotaExceeded, action, quota.UsedToday, quota.Daily, quota.UsedWeek, quota.Weekly)
+	}
+	return nil
+}
+
+// consumeQuota count one more action done, failures are only logged
+// because the action already happened in browser and must still be recorded
+func consumeQuota(action workflow.Action) {
+	if err := quotaStore.Increment(string(action), time.Now()); err != nil {
+		log.Printf("failed to count %s quota: %v", action, err)
+	}
+}
+
+// quotaStatus show how many mutating actions still can be done
+// this function handle for quota status command
+func quotaStatus(cmd *cobra.Command, args []string) error {
+	actions := []workflow.Action{workflow.ActionFollow, workflow.ActionConnect, workflow.ActionComment, workflow.ActionMessage}
+	for action := range configs.Quota {
+		if !slices.Contains(actions, workflow.Action(action)) {
+			actions = append(actions, workflow.Action(action))
+		}
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ACTION\tTODAY\tDAILY\tLAST 7 DAYS\tWEEKLY\tREMAINING")
+	for _, action := range actions {
+		quota, err := getQuota(action)
+		if err != nil {
+			return err
+		}
+		remaining := "unlimited"
+		if quota.Remaining() >= 0 {
+			remaining = fmt.Sprint(quota.Remaining())
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%d\t%s\t%s\n",
+			action, quota.UsedToday, limitText(quota.Daily), quota.UsedWeek, limitText(quota.Weekly), remaining)
+	}
+	return writer.Flush()
+}
+
+func limitText(limit int) string {
+	if limit <= 0 {
+		return "-"
+	}
+	return fmt.Sprint(limit)
+}
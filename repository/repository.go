@@ -6,4 +6,5 @@ type PostStore interface {
 	Upsert(post *domain.Post) *domain.Post
 	GetByID(id uint64) (*domain.Post, error)
 	GetByUrl(url string) (*domain.Post, error)
+	GetByUrn(urn domain.URN) (*domain.Post, error)
 }
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/gocarina/gocsv"
+	"github.com/spf13/cobra"
+	"github.com/victorfernandesraton/lazydin/adapters"
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+// searchOptions holds flags shared by search command and his verticals
+type searchOptions struct {
+	query      string
+	outputFile string
+	separator  string
+	limit      int
+	maxPages   int
+}
+
+// loader collect search results html from current page
+type loader func(ctx context.Context, limit, maxPages int) ([]string, error)
+
+var searchVerticals = []cobra.Command{
+	{
+		Use:   "people",
+		Short: "Search for people on Linkedin, storing them as authors",
+		RunE:  searchPeople,
+	},
+	{
+		Use:   "jobs",
+		Short: "Search for jobs on Linkedin",
+		RunE:  searchJobs,
+	},
+	{
+		Use:   "companies",
+		Short: "Search for companies on Linkedin",
+		RunE:  searchCompanies,
+	},
+}
+
+// addSearchFlags define flags shared by search command and his verticals
+func addSearchFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(flagQuery, "q", "", "Query for search")
+	cmd.Flags().StringP(flagOutput, "o", "", "Output file as csv")
+	cmd.Flags().StringP(flagSeparator, "", ";", "Output file as csv separator")
+	cmd.Flags().IntP(flagLimit, "l", 0, "Max results to collect, 0 for no limit")
+	cmd.Flags().IntP(flagMaxPages, "", 5, "Max result pages to scroll, 0 for no limit")
+}
+
+func readSearchOptions(cmd *cobra.Command) (options searchOptions, err error) {
+	if options.query, err = cmd.Flags().GetString(flagQuery); err != nil {
+		return options, fmt.Errorf("failed to get query flag: %w", err)
+	}
+	if options.query == "" {
+		return options, errors.New("query flag is required")
+	}
+
+	if options.outputFile, err = cmd.Flags().GetString(flagOutput); err != nil {
+		return options, fmt.Errorf("failed to get output flag: %w", err)
+	}
+	if !strings.HasSuffix(options.outputFile, ".csv") && options.outputFile != "" {
+		return options, fmt.Errorf("invalid file format for output, got %v, but only supported is .csv", options.outputFile)
+	}
+
+	if options.separator, err = cmd.Flags().GetString(flagSeparator); err != nil {
+		return options, fmt.Errorf("failed to get csv separator: %w", err)
+	}
+	if options.separator == "" {
+		return options, errors.New("csv separator can not be empty")
+	}
+
+	if options.limit, err = cmd.Flags().GetInt(flagLimit); err != nil {
+		return options, fmt.Errorf("failed to get limit flag: %w", err)
+	}
+
+	if options.maxPages, err = cmd.Flags().GetInt(flagMaxPages); err != nil {
+		return options, fmt.Errorf("failed to get max pages flag: %w", err)
+	}
+	return options, nil
+}
+
+// runSearch login, open search page with tasks and collect results with load
+func runSearch(options searchOptions, search chromedp.Tasks, load loader) ([]string, error) {
+	credentials, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel, err := startBrowser(credentials)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if err := workflow.Run(ctx,
+		workflow.Login(credentials.Username, credentials.Password, challengeResolver(credentials)), search,
+	); err != nil {
+		return nil, fmt.Errorf("failed to execute chromedp tasks: %w", err)
+	}
+
+	content, err := load(ctx, options.limit, options.maxPages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract outer HTML: %w", err)
+	}
+	return content, nil
+}
+
+// writeCSV export a slice of domain structs as csv
+func writeCSV(outputFile, separator string, result any) error {
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	csvWriter := csv.NewWriter(file)
+	runeSeparator := []rune(separator)
+	csvWriter.Comma = runeSeparator[0]
+	if err := gocsv.MarshalCSV(result, csvWriter); err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// searchPosts handles the search-posts command
+func searchPosts(cmd *cobra.Command, args []string) error {
+	options, err := readSearchOptions(cmd)
+	if err != nil {
+		return err
+	}
+	search, err := searchFilters(cmd, options.query)
+	if err != nil {
+		return err
+	}
+	postedSince, err := readPostedSince(cmd)
+	if err != nil {
+		return err
+	}
+	downloadMedia, err := cmd.Flags().GetBool(flagDownloadMedia)
+	if err != nil {
+		return fmt.Errorf("failed to get download media flag: %w", err)
+	}
+
+	content, err := runSearch(options, workflow.SearchForPosts(search), workflow.LoadPosts)
+	if err != nil {
+		return err
+	}
+
+	result, err := adapters.ExtractContent(content)
+	if err != nil {
+		return fmt.Errorf("failed to extract content: %w", err)
+	}
+	if !postedSince.IsZero() {
+		result = postedAfter(result, postedSince)
+	}
+	if downloadMedia {
+		files := storage.NewMediaFiles(filepath.Join(filepath.Dir(configs.SQlite), mediaDir))
+		for _, v := range result {
+			if err := files.Save(v.Post.Urn, v.Post.Media); err != nil {
+				log.Printf("failed to save media of %s: %v", v.Post.Url, err)
+			}
+		}
+	}
+	if options.outputFile != "" {
+		return writeCSV(options.outputFile, options.separator, &result)
+	}
+
+	for _, v := range result {
+		author, err := authorStore.Upsert(&v.Author)
+		if err != nil {
+			return err
+		}
+		v.Post.AuthorId = author.ID
+		post, err := postsStore.Upsert(&v.Post)
+		if err != nil {
+			return err
+		}
+		if _, err := mediaStore.ReplaceByPost(post.ID, v.Post.Media); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postedAfter keep contents with posts published after since, posts with unknown age are dropped
+func postedAfter(contents []domain.Content, since time.Time) []domain.Content {
+	var result []domain.Content
+	for _, v := range contents {
+		if !v.Post.PostedAt.IsZero() && !v.Post.PostedAt.Before(since) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// searchFilters build post search using filter flags from search command
+func searchFilters(cmd *cobra.Command, query string) (search workflow.PostSearch, err error) {
+	search.Query = query
+	if search.DatePosted, err = cmd.Flags().GetString(flagDatePosted); err != nil {
+		return search, fmt.Errorf("failed to get date posted flag: %w", err)
+	}
+	if search.SortBy, err = cmd.Flags().GetString(flagSort); err != nil {
+		return search, fmt.Errorf("failed to get sort flag: %w", err)
+	}
+	if search.ContentType, err = cmd.Flags().GetString(flagContentType); err != nil {
+		return search, fmt.Errorf("failed to get content type flag: %w", err)
+	}
+	if search.FromMember, err = cmd.Flags().GetStringSlice(flagFromMember); err != nil {
+		return search, fmt.Errorf("failed to get from member flag: %w", err)
+	}
+	if search.AuthorCompany, err = cmd.Flags().GetStringSlice(flagAuthorCompany); err != nil {
+		return search, fmt.Errorf("failed to get author company flag: %w", err)
+	}
+	return search, search.Validate()
+}
+
+// searchPeople handles the search people command, people are stored as authors
+func searchPeople(cmd *cobra.Command, args []string) error {
+	options, err := readSearchOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	content, err := runSearch(options, workflow.SearchForPeople(options.query), workflow.LoadEntities)
+	if err != nil {
+		return err
+	}
+
+	result, err := adapters.ExtractPeople(content)
+	if err != nil {
+		return fmt.Errorf("failed to extract people: %w", err)
+	}
+	if options.outputFile != "" {
+		return writeCSV(options.outputFile, options.separator, &result)
+	}
+
+	for _, v := range result {
+		if _, err := authorStore.Upsert(&v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchJobs handles the search jobs command
+func searchJobs(cmd *cobra.Command, args []string) error {
+	options, err := readSearchOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	content, err := runSearch(options, workflow.SearchForJobs(options.query), workflow.LoadJobs)
+	if err != nil {
+		return err
+	}
+
+	result, err := adapters.ExtractJobs(content)
+	if err != nil {
+		return fmt.Errorf("failed to extract jobs: %w", err)
+	}
+	if options.outputFile != "" {
+		return writeCSV(options.outputFile, options.separator, &result)
+	}
+
+	for _, v := range result {
+		if _, err := jobStore.Upsert(&v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchCompanies handles the search companies command
+func searchCompanies(cmd *cobra.Command, args []string) error {
+	options, err := readSearchOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	content, err := runSearch(options, workflow.SearchForCompanies(options.query), workflow.LoadEntities)
+	if err != nil {
+		return err
+	}
+
+	result, err := adapters.ExtractCompanies(content)
+	if err != nil {
+		return fmt.Errorf("failed to extract companies: %w", err)
+	}
+	if options.outputFile != "" {
+		return writeCSV(options.outputFile, options.separator, &result)
+	}
+
+	for _, v := range result {
+		if _, err := companyStore.Upsert(&v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
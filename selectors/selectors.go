@@ -0,0 +1,152 @@
+// Package selectors holds the XPath and CSS selectors used to find linkedin elements,
+// loaded from an embedded default file that users can override
+package selectors
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+//go:embed selectors.toml
+var defaults []byte
+
+// Selector is a named list of fallbacks, tried in order until one match
+type Selector struct {
+	Key       string
+	Fallbacks []string
+}
+
+func (s Selector) String() string {
+	return s.Key
+}
+
+var (
+	mu       sync.RWMutex
+	registry = mustDefault()
+)
+
+func mustDefault() *viper.Viper {
+	v, err := newRegistry()
+	if err != nil {
+		panic(fmt.Errorf("invalid default selectors: %w", err))
+	}
+	return v
+}
+
+func newRegistry() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewReader(defaults)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Load merge selectors from file over the embedded defaults, a missing file keep the defaults
+func Load(path string) error {
+	v, err := newRegistry()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := v.MergeConfig(bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("failed to read selectors from %s: %w", path, err)
+	}
+	for _, key := range v.AllKeys() {
+		if key == "version" {
+			continue
+		}
+		if _, err := fallbacks(v, key); err != nil {
+			return fmt.Errorf("invalid selector %s in %s: %w", key, path, err)
+		}
+	}
+
+	mu.Lock()
+	registry = v
+	mu.Unlock()
+	return nil
+}
+
+// Reset discard loaded overrides, keeping only the embedded defaults
+func Reset() {
+	mu.Lock()
+	registry = mustDefault()
+	mu.Unlock()
+}
+
+// Version returns the version of selectors file in use
+func Version() int {
+	mu.RLock()
+	defer mu.RUnlock()
+	return registry.GetInt("version")
+}
+
+// Get returns the selector of key, like "search.post", with his fallbacks
+func Get(key string) Selector {
+	mu.RLock()
+	defer mu.RUnlock()
+	values, _ := fallbacks(registry, key)
+	return Selector{Key: key, Fallbacks: values}
+}
+
+// Keys returns every selector key in use, sorted
+func Keys() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	var keys []string
+	for _, key := range registry.AllKeys() {
+		if key != "version" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fallbacks read key as a list of selectors, accepting a single string too
+func fallbacks(v *viper.Viper, key string) ([]string, error) {
+	var values []string
+	switch value := v.Get(key).(type) {
+	case nil:
+		return nil, nil
+	case string:
+		values = []string{value}
+	case []any:
+		for _, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expect string, got %v", item)
+			}
+			values = append(values, s)
+		}
+	case []string:
+		values = value
+	default:
+		return nil, fmt.Errorf("expect string or list of strings, got %v", value)
+	}
+
+	result := make([]string, 0, len(values))
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no selector defined")
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package selectors_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+func TestDefaults(t *testing.T) {
+	selectors.Reset()
+	if selectors.Version() < 1 {
+		t.Fatalf("expect selectors version, got %d", selectors.Version())
+	}
+	keys := selectors.Keys()
+	if len(keys) == 0 {
+		t.Fatalf("expect default selectors")
+	}
+	for _, key := range keys {
+		if len(selectors.Get(key).Fallbacks) == 0 {
+			t.Fatalf("expect fallbacks for %s", key)
+		}
+	}
+	if got := selectors.Get("unknown.key"); len(got.Fallbacks) != 0 || got.Key != "unknown.key" {
+		t.Fatalf("expect empty selector for unknown key, got %v", got.Fallbacks)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	defer selectors.Reset()
+	dir := t.TempDir()
+
+	t.Run("missing file keep defaults", func(t *testing.T) {
+		if err := selectors.Load(filepath.Join(dir, "missing.toml")); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(selectors.Get("search.post").Fallbacks) != 1 {
+			t.Fatalf("expect default post selector, got %v", selectors.Get("search.post").Fallbacks)
+		}
+	})
+
+	t.Run("override with fallbacks", func(t *testing.T) {
+		file := filepath.Join(dir, "selectors.toml")
+		content := `
+[search]
+post = ["ul.new-results > li", "//ul[@role='list']/li"]
+
+[login]
+username = "input#session_key"
+`
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if err := selectors.Load(file); err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		post := selectors.Get("search.post").Fallbacks
+		if len(post) != 2 || post[0] != "ul.new-results > li" || post[1] != "//ul[@role='list']/li" {
+			t.Fatalf("expect overridden fallbacks in order, got %v", post)
+		}
+		username := selectors.Get("login.username").Fallbacks
+		if len(username) != 1 || username[0] != "input#session_key" {
+			t.Fatalf("expect single string selector, got %v", username)
+		}
+		if len(selectors.Get("login.password").Fallbacks) == 0 {
+			t.Fatalf("expect not overridden selectors to keep defaults")
+		}
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		file := filepath.Join(dir, "invalid.toml")
+		if err := os.WriteFile(file, []byte("[search]\npost = []\n"), 0644); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if err := selectors.Load(file); err == nil {
+			t.Fatalf("expect error for empty selector")
+		}
+	})
+}
@@ -20,21 +20,56 @@ const (
 	`
 
 	upsertAuthorQuery = `
-		INSERT INTO authors (url, name, description, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?)
-		ON CONFLICT(url) DO UPDATE SET name=excluded.name, description=excluded.description, updated_at=excluded.updated_at
+		INSERT INTO authors (url, name, description, avatar_url, degree, followers, premium, verified, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET name=excluded.name, description=excluded.description,
+			avatar_url=COALESCE(NULLIF(excluded.avatar_url, ''), authors.avatar_url),
+			degree=COALESCE(NULLIF(excluded.degree, 0), authors.degree),
+			followers=COALESCE(NULLIF(excluded.followers, 0), authors.followers),
+			premium=MAX(authors.premium, excluded.premium), verified=MAX(authors.verified, excluded.verified),
+			updated_at=excluded.updated_at
 		RETURNING id;
 	`
 
+	authorColumns = `id, url, name, description, avatar_url, degree, followers, premium, verified, created_at, updated_at`
+
 	selectAuthorByIdQuery = `
-		SELECT id, url, name, description, created_at, updated_at FROM authors WHERE id = ?;
+		SELECT ` + authorColumns + ` FROM authors WHERE id = ?;
 	`
 
 	selectAuthorByUrlQuery = `
-		SELECT id, url, name, description, created_at, updated_at FROM authors WHERE url = ?;
+		SELECT ` + authorColumns + ` FROM authors WHERE url = ?;
 	`
 )
 
+// authorProfileColumns were added after authors table, so they are migrated in existing databases
+var authorProfileColumns = []column{
+	{"avatar_url", "TEXT DEFAULT ''"},
+	{"degree", "INTEGER DEFAULT 0"},
+	{"followers", "INTEGER DEFAULT 0"},
+	{"premium", "BOOLEAN DEFAULT 0"},
+	{"verified", "BOOLEAN DEFAULT 0"},
+}
+
+// scanner is implemented by sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// authorFields returns pointers to author fields in authorColumns order
+func authorFields(author *domain.Author) []any {
+	return []any{&author.ID, &author.Url, &author.Name, &author.Description, &author.AvatarUrl,
+		&author.Degree, &author.Followers, &author.Premium, &author.Verified, &author.CreatedAt, &author.UpdatedAt}
+}
+
+func scanAuthor(row scanner) (*domain.Author, error) {
+	var author domain.Author
+	if err := row.Scan(authorFields(&author)...); err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
 type AuthorStorage struct {
 	db *sql.DB
 }
@@ -44,13 +79,16 @@ func NewAuthorStorage(db *sql.DB) *AuthorStorage {
 }
 
 func (as *AuthorStorage) CreateTable() error {
-	_, err := as.db.Exec(createAuthorTableQuery)
-	return err
+	if _, err := as.db.Exec(createAuthorTableQuery); err != nil {
+		return err
+	}
+	return addColumns(as.db, "authors", authorProfileColumns)
 }
 
 func (as *AuthorStorage) Upsert(author *domain.Author) (*domain.Author, error) {
 	now := time.Now()
-	err := as.db.QueryRow(upsertAuthorQuery, author.Url, author.Name, author.Description, now, now).
+	err := as.db.QueryRow(upsertAuthorQuery, author.Url, author.Name, author.Description,
+		author.AvatarUrl, author.Degree, author.Followers, author.Premium, author.Verified, now, now).
 		Scan(&author.ID)
 	if err != nil {
 		return nil, err
@@ -59,21 +97,68 @@ func (as *AuthorStorage) Upsert(author *domain.Author) (*domain.Author, error) {
 }
 
 func (as *AuthorStorage) GetById(id uint64) (*domain.Author, error) {
-	var author domain.Author
-	err := as.db.QueryRow(selectAuthorByIdQuery, id).
-		Scan(&author.ID, &author.Url, &author.Name, &author.Description, &author.CreatedAt, &author.UpdatedAt)
-	if err != nil {
-		return nil, err
-	}
-	return &author, nil
+	return scanAuthor(as.db.QueryRow(selectAuthorByIdQuery, id))
 }
 
 func (as *AuthorStorage) GetByUrl(url string) (*domain.Author, error) {
-	var author domain.Author
-	err := as.db.QueryRow(selectAuthorByUrlQuery, url).
-		Scan(&author.ID, &author.Url, &author.Name, &author.Description, &author.CreatedAt, &author.UpdatedAt)
+	return scanAuthor(as.db.QueryRow(selectAuthorByUrlQuery, url))
+}
+
+// AuthorFilter select authors to run batch actions
+type AuthorFilter struct {
+	// PostKeyword keep only authors with some post containing it
+	PostKeyword string
+	// PostedSince keep only authors with some post published after it, zero for any time
+	PostedSince time.Time
+	// NotFollowed keep only authors without following relationship
+	NotFollowed bool
+	// Degree keep only authors with this connection degree, zero for any
+	Degree int
+	Limit  int
+}
+
+func (as *AuthorStorage) List(filter AuthorFilter) ([]domain.Author, error) {
+	query := `SELECT ` + authorColumns + ` FROM authors WHERE 1 = 1`
+	var args []any
+	if filter.PostKeyword != "" || !filter.PostedSince.IsZero() {
+		query += ` AND EXISTS (SELECT 1 FROM posts WHERE posts.author_id = authors.id`
+		if filter.PostKeyword != "" {
+			query += ` AND posts.content LIKE '%' || ? || '%'`
+			args = append(args, filter.PostKeyword)
+		}
+		if !filter.PostedSince.IsZero() {
+			query += ` AND posts.posted_at >= ?`
+			args = append(args, filter.PostedSince.UTC())
+		}
+		query += `)`
+	}
+	if filter.NotFollowed {
+		query += ` AND NOT EXISTS (SELECT 1 FROM relationships WHERE relationships.author_id = authors.id AND relationships.relation = ?)`
+		args = append(args, domain.RelationFollowing)
+	}
+	if filter.Degree > 0 {
+		query += ` AND degree = ?`
+		args = append(args, filter.Degree)
+	}
+	query += ` ORDER BY id`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := as.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	return &author, nil
+	defer rows.Close()
+
+	var authors []domain.Author
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, *author)
+	}
+	return authors, rows.Err()
 }
@@ -3,6 +3,7 @@ package storage_test
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/victorfernandesraton/lazydin/domain"
 	"github.com/victorfernandesraton/lazydin/storage"
@@ -70,4 +71,124 @@ func TestAuthorStorage(t *testing.T) {
 		}
 	})
 
+	t.Run("store profile", func(t *testing.T) {
+		author, err := authorStorage.Upsert(&domain.Author{
+			Url: "some-url", Name: "Captain Jack Sparrow", AvatarUrl: "avatar-url", Degree: 2, Followers: 1200, Verified: true,
+		})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if author.AvatarUrl != "avatar-url" || author.Degree != 2 || author.Followers != 1200 || !author.Verified || author.Premium {
+			t.Fatalf("unexpected profile %+v", author)
+		}
+
+		author, err = authorStorage.Upsert(&domain.Author{Url: "some-url", Name: "Captain Jack Sparrow"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if author.AvatarUrl != "avatar-url" || author.Degree != 2 || author.Followers != 1200 || !author.Verified {
+			t.Fatalf("expect avatar, degree, followers and badges kept when unknown, got %+v", author)
+		}
+	})
+
+	t.Run("list authors", func(t *testing.T) {
+		postStorage := storage.NewPostStorage(databse)
+		relationshipStorage := storage.NewRelationshipStorage(databse)
+		if err := postStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if err := relationshipStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+		recruiter, err := authorStorage.Upsert(&domain.Author{Url: "recruiter-url", Name: "Recruiter"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if _, err := postStorage.Upsert(&domain.Post{Url: "urn:li:activity:1", Content: "Hiring golang", AuthorId: recruiter.ID,
+			PostedAt: domain.NewTimestamp(time.Now().Add(-3 * time.Hour))}); err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		authors, err := authorStorage.List(storage.AuthorFilter{})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(authors) != 2 {
+			t.Fatalf("expect %d authors, got %d", 2, len(authors))
+		}
+
+		authors, err = authorStorage.List(storage.AuthorFilter{PostKeyword: "golang"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(authors) != 1 || authors[0].ID != recruiter.ID {
+			t.Fatalf("expect only recruiter, got %v", authors)
+		}
+
+		authors, err = authorStorage.List(storage.AuthorFilter{PostedSince: time.Now().Add(-24 * time.Hour)})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(authors) != 1 || authors[0].ID != recruiter.ID {
+			t.Fatalf("expect only recruiter with recent post, got %v", authors)
+		}
+		authors, err = authorStorage.List(storage.AuthorFilter{PostedSince: time.Now().Add(-time.Hour)})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(authors) != 0 {
+			t.Fatalf("expect no authors with posts in last hour, got %v", authors)
+		}
+
+		authors, err = authorStorage.List(storage.AuthorFilter{Degree: 2})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(authors) != 1 || authors[0].Url != "some-url" {
+			t.Fatalf("expect only 2nd degree author, got %v", authors)
+		}
+
+		if _, err := relationshipStorage.Upsert(&domain.Relationship{AuthorId: recruiter.ID, Relation: domain.RelationFollowing}); err != nil {
+			t.Fatalf(err.Error())
+		}
+		authors, err = authorStorage.List(storage.AuthorFilter{NotFollowed: true, Limit: 10})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(authors) != 1 || authors[0].ID != 1 {
+			t.Fatalf("expect only not followed author, got %v", authors)
+		}
+	})
+}
+
+func TestAuthorStorageMigration(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	_, err = databse.Exec(`
+		CREATE TABLE authors (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT UNIQUE,
+			name TEXT,
+			description TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO authors (url, name, description) VALUES ('some-url', 'Victor Raton', 'Developer');
+	`)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	authorStorage := storage.NewAuthorStorage(databse)
+	if err := authorStorage.CreateTable(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	author, err := authorStorage.GetByUrl("some-url")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if author.Name != "Victor Raton" || author.Degree != 0 || author.AvatarUrl != "" || author.Premium {
+		t.Fatalf("unexpected migrated author %+v", author)
+	}
 }
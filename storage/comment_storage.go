@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	createCommentTableQuery = `
+		CREATE TABLE IF NOT EXISTS comments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			post_id INTEGER,
+			content TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(post_id) REFERENCES posts(id)
+		);
+	`
+
+	insertCommentQuery = `
+		INSERT INTO comments (post_id, content, created_at)
+		VALUES (?, ?, ?)
+		RETURNING id;
+	`
+
+	selectCommentByIdQuery = `
+		SELECT id, post_id, content, created_at FROM comments WHERE id = ?;
+	`
+
+	selectCommentsByPostQuery = `
+		SELECT id, post_id, content, created_at FROM comments WHERE post_id = ? ORDER BY created_at;
+	`
+)
+
+type CommentStorage struct {
+	db *sql.DB
+}
+
+func NewCommentStorage(db *sql.DB) *CommentStorage {
+	return &CommentStorage{db: db}
+}
+
+func (cs *CommentStorage) CreateTable() error {
+	_, err := cs.db.Exec(createCommentTableQuery)
+	return err
+}
+
+func (cs *CommentStorage) Create(comment *domain.Comment) (*domain.Comment, error) {
+	err := cs.db.QueryRow(insertCommentQuery, comment.PostId, comment.Content, time.Now()).
+		Scan(&comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cs.GetById(comment.ID)
+}
+
+func (cs *CommentStorage) GetById(id uint64) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := cs.db.QueryRow(selectCommentByIdQuery, id).
+		Scan(&comment.ID, &comment.PostId, &comment.Content, &comment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (cs *CommentStorage) GetByPost(postId uint64) ([]domain.Comment, error) {
+	rows, err := cs.db.Query(selectCommentsByPostQuery, postId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []domain.Comment
+	for rows.Next() {
+		var comment domain.Comment
+		if err := rows.Scan(&comment.ID, &comment.PostId, &comment.Content, &comment.CreatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
@@ -0,0 +1,59 @@
+package storage_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestCommentStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	postStorage := storage.NewPostStorage(databse)
+	commentStorage := storage.NewCommentStorage(databse)
+	if err := postStorage.CreateTable(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	t.Run("create table", func(t *testing.T) {
+		if err := commentStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+	})
+
+	post, err := postStorage.Upsert(&domain.Post{Url: "urn:li:activity:1", Content: "some content"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("create comment", func(t *testing.T) {
+		comment, err := commentStorage.Create(&domain.Comment{PostId: post.ID, Content: "Nice post"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if comment.ID != 1 {
+			t.Fatalf("Comment shoud be using id 1")
+		}
+		if comment.Content != "Nice post" {
+			t.Fatalf("Comment content error, expect %s, got %s", "Nice post", comment.Content)
+		}
+	})
+
+	t.Run("get by post", func(t *testing.T) {
+		if _, err := commentStorage.Create(&domain.Comment{PostId: post.ID, Content: "Another one"}); err != nil {
+			t.Fatalf(err.Error())
+		}
+		comments, err := commentStorage.GetByPost(post.ID)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(comments) != 2 {
+			t.Fatalf("expect %d comments, got %d", 2, len(comments))
+		}
+	})
+}
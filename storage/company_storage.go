@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	createCompanyTableQuery = `
+		CREATE TABLE IF NOT EXISTS companies (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT UNIQUE,
+			name TEXT,
+			description TEXT,
+			followers TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+
+	upsertCompanyQuery = `
+		INSERT INTO companies (url, name, description, followers, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET name=excluded.name, description=excluded.description, followers=excluded.followers, updated_at=excluded.updated_at
+		RETURNING id;
+	`
+
+	selectCompanyByIdQuery = `
+		SELECT id, url, name, description, followers, created_at, updated_at FROM companies WHERE id = ?;
+	`
+)
+
+type CompanyStorage struct {
+	db *sql.DB
+}
+
+func NewCompanyStorage(db *sql.DB) *CompanyStorage {
+	return &CompanyStorage{db: db}
+}
+
+func (cs *CompanyStorage) CreateTable() error {
+	_, err := cs.db.Exec(createCompanyTableQuery)
+	return err
+}
+
+func (cs *CompanyStorage) Upsert(company *domain.Company) (*domain.Company, error) {
+	now := time.Now()
+	err := cs.db.QueryRow(upsertCompanyQuery, company.Url, company.Name, company.Description, company.Followers, now, now).
+		Scan(&company.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cs.GetById(company.ID)
+}
+
+func (cs *CompanyStorage) GetById(id uint64) (*domain.Company, error) {
+	var company domain.Company
+	err := cs.db.QueryRow(selectCompanyByIdQuery, id).
+		Scan(&company.ID, &company.Url, &company.Name, &company.Description, &company.Followers, &company.CreatedAt, &company.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	createJobTableQuery = `
+		CREATE TABLE IF NOT EXISTS jobs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			job_id TEXT UNIQUE,
+			url TEXT,
+			title TEXT,
+			company TEXT,
+			location TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+
+	upsertJobQuery = `
+		INSERT INTO jobs (job_id, url, title, company, location, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(job_id) DO UPDATE SET url=excluded.url, title=excluded.title, company=excluded.company, location=excluded.location, updated_at=excluded.updated_at
+		RETURNING id;
+	`
+
+	selectJobByIdQuery = `
+		SELECT id, job_id, url, title, company, location, created_at, updated_at FROM jobs WHERE id = ?;
+	`
+)
+
+type JobStorage struct {
+	db *sql.DB
+}
+
+func NewJobStorage(db *sql.DB) *JobStorage {
+	return &JobStorage{db: db}
+}
+
+func (js *JobStorage) CreateTable() error {
+	_, err := js.db.Exec(createJobTableQuery)
+	return err
+}
+
+func (js *JobStorage) Upsert(job *domain.Job) (*domain.Job, error) {
+	now := time.Now()
+	err := js.db.QueryRow(upsertJobQuery, job.JobId, job.Url, job.Title, job.Company, job.Location, now, now).
+		Scan(&job.ID)
+	if err != nil {
+		return nil, err
+	}
+	return js.GetById(job.ID)
+}
+
+func (js *JobStorage) GetById(id uint64) (*domain.Job, error) {
+	var job domain.Job
+	err := js.db.QueryRow(selectJobByIdQuery, id).
+		Scan(&job.ID, &job.JobId, &job.Url, &job.Title, &job.Company, &job.Location, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+// downloadTimeout limit each media download, avoiding a stalled server blocking the search
+const downloadTimeout = 2 * time.Minute
+
+// MediaFiles save images and documents of posts in a directory, usually next to the database
+type MediaFiles struct {
+	Dir    string
+	Client *http.Client
+}
+
+func NewMediaFiles(dir string) *MediaFiles {
+	return &MediaFiles{Dir: dir, Client: &http.Client{Timeout: downloadTimeout}}
+}
+
+// Save download each downloadable media of post into Dir/<post urn id>/<type>-<url hash>, filling his Path,
+// naming by url keep each file bound to the same media when attachments change between searches,
+// media failing to download are kept without Path and reported in returned error
+func (mf *MediaFiles) Save(urn domain.URN, media []domain.Media) error {
+	dir := filepath.Join(mf.Dir, urn.Id())
+	var errs []error
+	for i := range media {
+		if !media[i].Downloadable() {
+			continue
+		}
+		filePath, err := mf.download(media[i].Url, filepath.Join(dir, mediaFileName(media[i])))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to download %s %s: %w", media[i].Type, media[i].Url, err))
+			continue
+		}
+		media[i].Path = filePath
+	}
+	return errors.Join(errs...)
+}
+
+// mediaFileName name media file by type and hash of url, without extension
+func mediaFileName(media domain.Media) string {
+	sum := sha256.Sum256([]byte(media.Url))
+	return fmt.Sprintf("%s-%s", media.Type, hex.EncodeToString(sum[:])[:16])
+}
+
+// download save url in name, adding extension from url or content type
+func (mf *MediaFiles) download(address, name string) (string, error) {
+	response, err := mf.Client.Get(address)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	filePath := name + fileExtension(address, response.Header.Get("Content-Type"))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, response.Body); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+func fileExtension(address, contentType string) string {
+	if parsed, err := url.Parse(address); err == nil {
+		if ext := path.Ext(parsed.Path); ext != "" && len(ext) <= 5 {
+			return strings.ToLower(ext)
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/jpeg":
+		return ".jpg"
+	case "application/pdf":
+		return ".pdf"
+	}
+	if extensions, err := mime.ExtensionsByType(mediaType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ""
+}
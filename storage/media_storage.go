@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	createMediaTableQuery = `
+		CREATE TABLE IF NOT EXISTS post_media (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			post_id INTEGER,
+			type TEXT,
+			url TEXT,
+			title TEXT,
+			domain TEXT,
+			options TEXT,
+			path TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(post_id) REFERENCES posts(id)
+		);
+	`
+
+	deleteMediaByPostQuery = `
+		DELETE FROM post_media WHERE post_id = ?;
+	`
+
+	insertMediaQuery = `
+		INSERT INTO post_media (post_id, type, url, title, domain, options, path, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id;
+	`
+
+	selectMediaByPostQuery = `
+		SELECT id, post_id, type, url, title, domain, options, path, created_at FROM post_media WHERE post_id = ? ORDER BY id;
+	`
+)
+
+type MediaStorage struct {
+	db *sql.DB
+}
+
+func NewMediaStorage(db *sql.DB) *MediaStorage {
+	return &MediaStorage{db: db}
+}
+
+func (ms *MediaStorage) CreateTable() error {
+	_, err := ms.db.Exec(createMediaTableQuery)
+	return err
+}
+
+// ReplaceByPost store media of post, removing the ones stored by previous searches,
+// media without path keep the file downloaded before for same type and url
+func (ms *MediaStorage) ReplaceByPost(postId uint64, media []domain.Media) ([]domain.Media, error) {
+	previous, err := ms.GetByPost(postId)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[[2]string]string, len(previous))
+	for _, item := range previous {
+		if item.Path != "" {
+			paths[[2]string{item.Type, item.Url}] = item.Path
+		}
+	}
+	for i := range media {
+		if media[i].Path == "" {
+			media[i].Path = paths[[2]string{media[i].Type, media[i].Url}]
+		}
+	}
+
+	tx, err := ms.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(deleteMediaByPostQuery, postId); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range media {
+		media[i].PostId = postId
+		err := tx.QueryRow(insertMediaQuery, postId, media[i].Type, media[i].Url, media[i].Title,
+			media[i].Domain, media[i].Options, media[i].Path, now).
+			Scan(&media[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ms.GetByPost(postId)
+}
+
+func (ms *MediaStorage) GetByPost(postId uint64) ([]domain.Media, error) {
+	rows, err := ms.db.Query(selectMediaByPostQuery, postId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var media []domain.Media
+	for rows.Next() {
+		var item domain.Media
+		if err := rows.Scan(&item.ID, &item.PostId, &item.Type, &item.Url, &item.Title,
+			&item.Domain, &item.Options, &item.Path, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+		media = append(media, item)
+	}
+	return media, rows.Err()
+}
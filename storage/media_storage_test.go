@@ -0,0 +1,136 @@
+package storage_test
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestMediaStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	mediaStorage := storage.NewMediaStorage(databse)
+	if err := mediaStorage.CreateTable(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("store media of post", func(t *testing.T) {
+		media, err := mediaStorage.ReplaceByPost(1, []domain.Media{
+			{Type: domain.MediaImage, Url: "image-url", Path: "media/1/1-image.jpg"},
+			{Type: domain.MediaPoll, Options: domain.List{"Go", "Rust"}},
+			{Type: domain.MediaLink, Url: "link-url", Title: "Some article", Domain: "example.com"},
+		})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(media) != 3 {
+			t.Fatalf("expect %d media, got %d", 3, len(media))
+		}
+		if media[0].PostId != 1 || media[0].Path != "media/1/1-image.jpg" {
+			t.Fatalf("unexpected image %+v", media[0])
+		}
+		if media[1].Options.String() != "Go,Rust" || media[2].Domain != "example.com" {
+			t.Fatalf("unexpected poll %+v or link %+v", media[1], media[2])
+		}
+	})
+
+	t.Run("keep path of media downloaded before", func(t *testing.T) {
+		media, err := mediaStorage.ReplaceByPost(1, []domain.Media{
+			{Type: domain.MediaImage, Url: "image-url"},
+			{Type: domain.MediaDocument, Url: "image-url"},
+		})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(media) != 2 || media[0].Path != "media/1/1-image.jpg" || media[1].Path != "" {
+			t.Fatalf("expect only image path kept, got %+v", media)
+		}
+	})
+
+	t.Run("replace media of post", func(t *testing.T) {
+		media, err := mediaStorage.ReplaceByPost(1, []domain.Media{{Type: domain.MediaVideo, Url: "video-url"}})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(media) != 1 || media[0].Type != domain.MediaVideo {
+			t.Fatalf("expect only video, got %v", media)
+		}
+	})
+}
+
+func TestMediaFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/document" {
+			w.Header().Set("Content-Type", "application/pdf")
+		}
+		w.Write([]byte("content of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	files := storage.NewMediaFiles(t.TempDir())
+	media := []domain.Media{
+		{Type: domain.MediaImage, Url: server.URL + "/image.png?e=123"},
+		{Type: domain.MediaVideo, Url: server.URL + "/poster.jpg"},
+		{Type: domain.MediaDocument, Url: server.URL + "/document"},
+	}
+	if err := files.Save("urn:li:activity:7151313167762010113", media); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("keep media failing to download", func(t *testing.T) {
+		missing := []domain.Media{{Type: domain.MediaImage, Url: "http://127.0.0.1:1/image.png"}}
+		if err := files.Save("urn:li:activity:1", missing); err == nil || missing[0].Path != "" {
+			t.Fatalf("expect download error without path, got %v and %q", err, missing[0].Path)
+		}
+	})
+
+	dir := filepath.Join(files.Dir, "7151313167762010113")
+	expected := []struct{ prefix, ext string }{{"image-", ".png"}, {}, {"document-", ".pdf"}}
+	for i, file := range expected {
+		if file.prefix == "" {
+			if media[i].Path != "" {
+				t.Fatalf("expect %s not saved, got %q", media[i].Type, media[i].Path)
+			}
+			continue
+		}
+		name := filepath.Base(media[i].Path)
+		if filepath.Dir(media[i].Path) != dir || !strings.HasPrefix(name, file.prefix) || filepath.Ext(name) != file.ext {
+			t.Fatalf("expect %s saved in %s as %s*%s, got %q", media[i].Type, dir, file.prefix, file.ext, media[i].Path)
+		}
+		if _, err := os.Stat(media[i].Path); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	t.Run("keep file names when attachments change", func(t *testing.T) {
+		reordered := []domain.Media{
+			{Type: domain.MediaImage, Url: server.URL + "/new.png"},
+			{Type: domain.MediaDocument, Url: server.URL + "/document"},
+			{Type: domain.MediaImage, Url: server.URL + "/image.png?e=123"},
+		}
+		if err := files.Save("urn:li:activity:7151313167762010113", reordered); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if reordered[1].Path != media[2].Path || reordered[2].Path != media[0].Path || reordered[0].Path == media[0].Path {
+			t.Fatalf("expect same files for same urls, got %v and %v", reordered, media)
+		}
+		content, err := os.ReadFile(media[0].Path)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if string(content) != "content of /image.png" {
+			t.Fatalf("expect image file kept, got %q", content)
+		}
+	})
+}
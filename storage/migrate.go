@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// column is a column added to a table after it was created by older versions
+type column struct {
+	name       string
+	definition string
+}
+
+// addColumns alter table adding columns missing in databases created before them
+func addColumns(db *sql.DB, table string, columns []column) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s);", table))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, kind       string
+			defaultValue     sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &kind, &notNull, &defaultValue, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, c := range columns {
+		if existing[c.name] {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, c.name, c.definition)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
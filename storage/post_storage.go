@@ -21,21 +21,45 @@ const (
 	`
 
 	upsertPostQuery = `
-		INSERT INTO posts (url, content, author_id, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?)
-		ON CONFLICT(url) DO UPDATE SET content=excluded.content, author_id=excluded.author_id, updated_at=excluded.updated_at
+		INSERT INTO posts (urn, url, content, author_id, reactions, comments, reposts, relative_time, posted_at, hashtags, mentions, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET urn=excluded.urn, content=excluded.content, author_id=excluded.author_id,
+			reactions=excluded.reactions, comments=excluded.comments, reposts=excluded.reposts,
+			relative_time=excluded.relative_time, hashtags=excluded.hashtags, mentions=excluded.mentions,
+			posted_at=MIN(COALESCE(posts.posted_at, excluded.posted_at), COALESCE(excluded.posted_at, posts.posted_at)),
+			updated_at=excluded.updated_at
 		RETURNING id;
 	`
 
-	selectPostByIdQuery = `
-		SELECT id, url, content, author_id, created_at, updated_at FROM posts WHERE id = ?;
+	selectPostQuery = `
+		SELECT id, urn, url, content, author_id, reactions, comments, reposts, relative_time, posted_at, hashtags, mentions, created_at, updated_at FROM posts
 	`
 
-	selectPostByUrlQuery = `
-		SELECT id, url, content, author_id, created_at, updated_at FROM posts WHERE url = ?;
+	createPostUrnIndexQuery = `
+		CREATE UNIQUE INDEX IF NOT EXISTS posts_urn ON posts(urn) WHERE urn != '';
+	`
+
+	selectPostsWithoutUrnQuery = `
+		SELECT id, url FROM posts WHERE urn = '';
+	`
+
+	updatePostUrnQuery = `
+		UPDATE posts SET urn = ?, url = ? WHERE id = ?;
 	`
 )
 
+// postColumns were added after posts table, so they are migrated in existing databases
+var postColumns = []column{
+	{"reactions", "INTEGER DEFAULT 0"},
+	{"comments", "INTEGER DEFAULT 0"},
+	{"reposts", "INTEGER DEFAULT 0"},
+	{"relative_time", "TEXT DEFAULT ''"},
+	{"hashtags", "TEXT DEFAULT ''"},
+	{"mentions", "TEXT DEFAULT ''"},
+	{"urn", "TEXT DEFAULT ''"},
+	{"posted_at", "TIMESTAMP"},
+}
+
 type PostStorage struct {
 	db *sql.DB
 }
@@ -45,13 +69,54 @@ func NewPostStorage(db *sql.DB) *PostStorage {
 }
 
 func (ps *PostStorage) CreateTable() error {
-	_, err := ps.db.Exec(createPostTableQuery)
+	if _, err := ps.db.Exec(createPostTableQuery); err != nil {
+		return err
+	}
+	if err := addColumns(ps.db, "posts", postColumns); err != nil {
+		return err
+	}
+	if err := ps.migrateUrns(); err != nil {
+		return err
+	}
+	_, err := ps.db.Exec(createPostUrnIndexQuery)
 	return err
 }
 
+// migrateUrns move urns stored in url column by older versions to urn column, replacing url by permalink
+func (ps *PostStorage) migrateUrns() error {
+	rows, err := ps.db.Query(selectPostsWithoutUrnQuery)
+	if err != nil {
+		return err
+	}
+	posts := make(map[uint64]domain.URN)
+	for rows.Next() {
+		var id uint64
+		var url string
+		if err := rows.Scan(&id, &url); err != nil {
+			rows.Close()
+			return err
+		}
+		if urn, err := domain.ParseURN(url); err == nil {
+			posts[id] = urn
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, urn := range posts {
+		if _, err := ps.db.Exec(updatePostUrnQuery, urn, urn.Permalink(), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ps *PostStorage) Upsert(post *domain.Post) (*domain.Post, error) {
 	now := time.Now()
-	err := ps.db.QueryRow(upsertPostQuery, post.Url, post.Content, post.AuthorId, now, now).
+	err := ps.db.QueryRow(upsertPostQuery, post.Urn, post.Url, post.Content, post.AuthorId,
+		post.Reactions, post.Comments, post.Reposts, post.RelativeTime, post.PostedAt, post.Hashtags, post.Mentions, now, now).
 		Scan(&post.ID)
 	if err != nil {
 		return nil, err
@@ -60,19 +125,22 @@ func (ps *PostStorage) Upsert(post *domain.Post) (*domain.Post, error) {
 }
 
 func (ps *PostStorage) GetById(id uint64) (*domain.Post, error) {
-	var post domain.Post
-	err := ps.db.QueryRow(selectPostByIdQuery, id).
-		Scan(&post.ID, &post.Url, &post.Content, &post.AuthorId, &post.CreatedAt, &post.UpdatedAt)
-	if err != nil {
-		return nil, err
-	}
-	return &post, nil
+	return scanPost(ps.db.QueryRow(selectPostQuery+"WHERE id = ?;", id))
 }
 
 func (ps *PostStorage) GetByUrl(url string) (*domain.Post, error) {
+	return scanPost(ps.db.QueryRow(selectPostQuery+"WHERE url = ?;", url))
+}
+
+func (ps *PostStorage) GetByUrn(urn domain.URN) (*domain.Post, error) {
+	return scanPost(ps.db.QueryRow(selectPostQuery+"WHERE urn = ?;", urn))
+}
+
+func scanPost(row *sql.Row) (*domain.Post, error) {
 	var post domain.Post
-	err := ps.db.QueryRow(selectPostByUrlQuery, url).
-		Scan(&post.ID, &post.Url, &post.Content, &post.AuthorId, &post.CreatedAt, &post.UpdatedAt)
+	err := row.Scan(&post.ID, &post.Urn, &post.Url, &post.Content, &post.AuthorId,
+		&post.Reactions, &post.Comments, &post.Reposts, &post.RelativeTime, &post.PostedAt, &post.Hashtags, &post.Mentions,
+		&post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,111 @@
+package storage_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestPostStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	postStorage := storage.NewPostStorage(databse)
+	if err := postStorage.CreateTable(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	postedAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	t.Run("store engagement and hashtags", func(t *testing.T) {
+		post, err := postStorage.Upsert(&domain.Post{
+			Urn: "urn:li:activity:1", Url: "https://www.linkedin.com/feed/update/urn:li:activity:1/", Content: "some content", Reactions: 418, Comments: 45, Reposts: 22,
+			RelativeTime: "4mo", PostedAt: domain.NewTimestamp(postedAt), Hashtags: domain.List{"vagas", "remoto"}, Mentions: domain.List{"https://www.linkedin.com/in/someone"},
+		})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if post.Reactions != 418 || post.Comments != 45 || post.Reposts != 22 || post.RelativeTime != "4mo" {
+			t.Fatalf("unexpected engagement %+v", post)
+		}
+		if !reflect.DeepEqual(post.Hashtags, domain.List{"vagas", "remoto"}) || len(post.Mentions) != 1 {
+			t.Fatalf("unexpected hashtags %v and mentions %v", post.Hashtags, post.Mentions)
+		}
+	})
+
+	t.Run("update engagement", func(t *testing.T) {
+		if _, err := postStorage.Upsert(&domain.Post{Urn: "urn:li:activity:1", Url: "https://www.linkedin.com/feed/update/urn:li:activity:1/", Content: "some content", Reactions: 500}); err != nil {
+			t.Fatalf(err.Error())
+		}
+		post, err := postStorage.GetByUrn("urn:li:activity:1")
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if post.Reactions != 500 || len(post.Hashtags) != 0 {
+			t.Fatalf("expect updated engagement, got %+v", post)
+		}
+		if !post.PostedAt.Equal(postedAt) {
+			t.Fatalf("expect posted at kept when age is unknown, got %v", post.PostedAt)
+		}
+	})
+
+	t.Run("keep earliest posted at estimate", func(t *testing.T) {
+		for _, estimate := range []time.Time{postedAt.Add(24 * time.Hour), postedAt.Add(-time.Hour)} {
+			if _, err := postStorage.Upsert(&domain.Post{
+				Urn: "urn:li:activity:1", Url: "https://www.linkedin.com/feed/update/urn:li:activity:1/", Content: "some content", PostedAt: domain.NewTimestamp(estimate),
+			}); err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+		post, err := postStorage.GetByUrn("urn:li:activity:1")
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if !post.PostedAt.Equal(postedAt.Add(-time.Hour)) {
+			t.Fatalf("expect earliest estimate kept, got %v", post.PostedAt)
+		}
+	})
+}
+
+func TestPostStorageMigration(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	_, err = databse.Exec(`
+		CREATE TABLE posts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT UNIQUE,
+			content TEXT,
+			author_id INTEGER,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO posts (url, content, author_id) VALUES ('urn:li:activity:1', 'old post', 1);
+	`)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	postStorage := storage.NewPostStorage(databse)
+	for i := 0; i < 2; i++ {
+		if err := postStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+	post, err := postStorage.GetByUrn("urn:li:activity:1")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if post.Content != "old post" || post.Reactions != 0 || post.Hashtags != nil {
+		t.Fatalf("unexpected migrated post %+v", post)
+	}
+	if post.Url != "https://www.linkedin.com/feed/update/urn:li:activity:1/" {
+		t.Fatalf("expect url migrated to permalink, got %s", post.Url)
+	}
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	createProspectTableQuery = `
+		CREATE TABLE IF NOT EXISTS prospects (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			author_id INTEGER UNIQUE,
+			post_id INTEGER,
+			channel TEXT,
+			content TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(author_id) REFERENCES authors(id),
+			FOREIGN KEY(post_id) REFERENCES posts(id)
+		);
+	`
+
+	insertProspectQuery = `
+		INSERT INTO prospects (author_id, post_id, channel, content, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id;
+	`
+
+	selectProspectByIdQuery = `
+		SELECT id, author_id, post_id, channel, content, created_at FROM prospects WHERE id = ?;
+	`
+
+	selectProspectByAuthorQuery = `
+		SELECT id, author_id, post_id, channel, content, created_at FROM prospects WHERE author_id = ?;
+	`
+
+	selectProspectCandidatesQuery = `
+		SELECT a.id, a.url, a.name, a.description, a.avatar_url, a.degree, a.followers, a.premium, a.verified, a.created_at, a.updated_at,
+			p.id, p.urn, p.url, p.content, p.author_id, p.created_at, p.updated_at
+		FROM authors a
+		JOIN posts p ON p.id = (
+			SELECT MAX(id) FROM posts WHERE author_id = a.id AND content LIKE '%' || ? || '%'
+				AND (? IS NULL OR posted_at >= ?)
+		)
+		WHERE NOT EXISTS (SELECT 1 FROM prospects WHERE prospects.author_id = a.id)
+		ORDER BY p.updated_at DESC
+		LIMIT ?;
+	`
+)
+
+type ProspectStorage struct {
+	db *sql.DB
+}
+
+func NewProspectStorage(db *sql.DB) *ProspectStorage {
+	return &ProspectStorage{db: db}
+}
+
+func (ps *ProspectStorage) CreateTable() error {
+	_, err := ps.db.Exec(createProspectTableQuery)
+	return err
+}
+
+func (ps *ProspectStorage) Create(prospect *domain.Prospect) (*domain.Prospect, error) {
+	err := ps.db.QueryRow(insertProspectQuery, prospect.AuthorId, prospect.PostId, prospect.Channel, prospect.Content, time.Now()).
+		Scan(&prospect.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ps.GetById(prospect.ID)
+}
+
+func (ps *ProspectStorage) GetById(id uint64) (*domain.Prospect, error) {
+	var prospect domain.Prospect
+	err := ps.db.QueryRow(selectProspectByIdQuery, id).
+		Scan(&prospect.ID, &prospect.AuthorId, &prospect.PostId, &prospect.Channel, &prospect.Content, &prospect.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &prospect, nil
+}
+
+func (ps *ProspectStorage) GetByAuthor(authorId uint64) (*domain.Prospect, error) {
+	var prospect domain.Prospect
+	err := ps.db.QueryRow(selectProspectByAuthorQuery, authorId).
+		Scan(&prospect.ID, &prospect.AuthorId, &prospect.PostId, &prospect.Channel, &prospect.Content, &prospect.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &prospect, nil
+}
+
+// ListCandidates returns authors not contacted yet with their last post matching filter,
+// published after postedSince when it is not zero
+func (ps *ProspectStorage) ListCandidates(filter string, postedSince time.Time, limit int) ([]domain.Content, error) {
+	since := domain.NewTimestamp(postedSince)
+	rows, err := ps.db.Query(selectProspectCandidatesQuery, filter, since, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []domain.Content
+	for rows.Next() {
+		var content domain.Content
+		fields := append(authorFields(&content.Author),
+			&content.Post.ID, &content.Post.Urn, &content.Post.Url, &content.Post.Content, &content.Post.AuthorId,
+			&content.Post.CreatedAt, &content.Post.UpdatedAt,
+		)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		content.Post.AuthorUrl = content.Author.Url
+		contents = append(contents, content)
+	}
+	return contents, rows.Err()
+}
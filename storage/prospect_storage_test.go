@@ -0,0 +1,102 @@
+package storage_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestProspectStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	authorStorage := storage.NewAuthorStorage(databse)
+	postStorage := storage.NewPostStorage(databse)
+	prospectStorage := storage.NewProspectStorage(databse)
+	for _, create := range []func() error{authorStorage.CreateTable, postStorage.CreateTable} {
+		if err := create(); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+	t.Run("create table", func(t *testing.T) {
+		if err := prospectStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+	})
+
+	recruiter, err := authorStorage.Upsert(&domain.Author{Url: "recruiter-url", Name: "Recruiter"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	other, err := authorStorage.Upsert(&domain.Author{Url: "other-url", Name: "Other"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	job, err := postStorage.Upsert(&domain.Post{Url: "urn:li:activity:1", Content: "Hiring golang developer", AuthorId: recruiter.ID,
+		PostedAt: domain.NewTimestamp(time.Now().Add(-48 * time.Hour))})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if _, err := postStorage.Upsert(&domain.Post{Url: "urn:li:activity:2", Content: "Some motivational post", AuthorId: other.ID,
+		PostedAt: domain.NewTimestamp(time.Now().Add(-60 * 24 * time.Hour))}); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("list candidates with filter", func(t *testing.T) {
+		contents, err := prospectStorage.ListCandidates("golang", time.Time{}, 10)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(contents) != 1 {
+			t.Fatalf("expect %d candidates, got %d", 1, len(contents))
+		}
+		if contents[0].Author.ID != recruiter.ID || contents[0].Post.ID != job.ID {
+			t.Fatalf("unexpected candidate %v", contents[0])
+		}
+	})
+
+	t.Run("list candidates with limit", func(t *testing.T) {
+		contents, err := prospectStorage.ListCandidates("", time.Time{}, 1)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(contents) != 1 {
+			t.Fatalf("expect %d candidates, got %d", 1, len(contents))
+		}
+	})
+
+	t.Run("list candidates posted within", func(t *testing.T) {
+		contents, err := prospectStorage.ListCandidates("", time.Now().Add(-7*24*time.Hour), 10)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(contents) != 1 || contents[0].Author.ID != recruiter.ID {
+			t.Fatalf("expect only author with recent post, got %v", contents)
+		}
+	})
+
+	t.Run("skip contacted authors", func(t *testing.T) {
+		prospect, err := prospectStorage.Create(&domain.Prospect{
+			AuthorId: recruiter.ID, PostId: job.ID, Channel: domain.ProspectByMessage, Content: "Hi",
+		})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if prospect.ID != 1 {
+			t.Fatalf("Prospect shoud be using id 1")
+		}
+		contents, err := prospectStorage.ListCandidates("", time.Time{}, 10)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(contents) != 1 || contents[0].Author.ID != other.ID {
+			t.Fatalf("expect only not contacted author, got %v", contents)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	dayLayout = "2006-01-02"
+
+	createQuotaTableQuery = `
+		CREATE TABLE IF NOT EXISTS quotas (
+			action TEXT,
+			day TEXT,
+			count INTEGER DEFAULT 0,
+			PRIMARY KEY(action, day)
+		);
+	`
+
+	createMutationTableQuery = `
+		CREATE TABLE IF NOT EXISTS mutations (
+			action TEXT PRIMARY KEY,
+			last_at TIMESTAMP
+		);
+	`
+
+	upsertMutationQuery = `
+		INSERT INTO mutations (action, last_at)
+		VALUES (?, ?)
+		ON CONFLICT(action) DO UPDATE SET last_at=excluded.last_at;
+	`
+
+	selectMutationQuery = `
+		SELECT last_at FROM mutations WHERE action = ?;
+	`
+
+	incrementQuotaQuery = `
+		INSERT INTO quotas (action, day, count)
+		VALUES (?, ?, 1)
+		ON CONFLICT(action, day) DO UPDATE SET count=count + 1;
+	`
+
+	selectQuotaUsageQuery = `
+		SELECT
+			COALESCE(SUM(CASE WHEN day = ? THEN count ELSE 0 END), 0),
+			COALESCE(SUM(count), 0)
+		FROM quotas WHERE action = ? AND day >= ? AND day <= ?;
+	`
+)
+
+type QuotaStorage struct {
+	db *sql.DB
+}
+
+func NewQuotaStorage(db *sql.DB) *QuotaStorage {
+	return &QuotaStorage{db: db}
+}
+
+func (qs *QuotaStorage) CreateTable() error {
+	if _, err := qs.db.Exec(createQuotaTableQuery); err != nil {
+		return err
+	}
+	_, err := qs.db.Exec(createMutationTableQuery)
+	return err
+}
+
+// Increment count one more action done at given time
+func (qs *QuotaStorage) Increment(action string, at time.Time) error {
+	_, err := qs.db.Exec(incrementQuotaQuery, action, at.Format(dayLayout))
+	return err
+}
+
+// Get returns quota usage of action in the day and in the last 7 days of now
+func (qs *QuotaStorage) Get(action string, daily, weekly int, now time.Time) (*domain.Quota, error) {
+	quota := &domain.Quota{Action: action, Daily: daily, Weekly: weekly}
+	today := now.Format(dayLayout)
+	weekStart := now.AddDate(0, 0, -6).Format(dayLayout)
+	err := qs.db.QueryRow(selectQuotaUsageQuery, today, action, weekStart, today).
+		Scan(&quota.UsedToday, &quota.UsedWeek)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// LastMutation returns when action was done last, zero when never done,
+// used to keep the minimum interval between mutating actions of separated runs
+func (qs *QuotaStorage) LastMutation(action string) (time.Time, error) {
+	var last time.Time
+	err := qs.db.QueryRow(selectMutationQuery, action).Scan(&last)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return last, err
+}
+
+// SetLastMutation store when action was done last
+func (qs *QuotaStorage) SetLastMutation(action string, at time.Time) error {
+	_, err := qs.db.Exec(upsertMutationQuery, action, at.UTC())
+	return err
+}
@@ -0,0 +1,81 @@
+package storage_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestQuotaStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	quotaStorage := storage.NewQuotaStorage(databse)
+	t.Run("create table", func(t *testing.T) {
+		if err := quotaStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+	})
+
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.Local)
+	t.Run("increment and get usage", func(t *testing.T) {
+		for _, at := range []time.Time{now, now, now.AddDate(0, 0, -3), now.AddDate(0, 0, -8)} {
+			if err := quotaStorage.Increment("follow", at); err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+		if err := quotaStorage.Increment("comment", now); err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		quota, err := quotaStorage.Get("follow", 10, 20, now)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if quota.UsedToday != 2 {
+			t.Fatalf("expect %d used today, got %d", 2, quota.UsedToday)
+		}
+		if quota.UsedWeek != 3 {
+			t.Fatalf("expect %d used in week, got %d", 3, quota.UsedWeek)
+		}
+		if quota.Remaining() != 8 {
+			t.Fatalf("expect %d remaining, got %d", 8, quota.Remaining())
+		}
+	})
+
+	t.Run("get without usage", func(t *testing.T) {
+		quota, err := quotaStorage.Get("message", 5, 0, now)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if quota.UsedToday != 0 || quota.UsedWeek != 0 {
+			t.Fatalf("expect no usage, got %v", quota)
+		}
+	})
+
+	t.Run("keep last mutation", func(t *testing.T) {
+		last, err := quotaStorage.LastMutation("follow")
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if !last.IsZero() {
+			t.Fatalf("expect no mutation yet, got %v", last)
+		}
+		for _, at := range []time.Time{now.Add(-time.Minute), now} {
+			if err := quotaStorage.SetLastMutation("follow", at); err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+		if last, err = quotaStorage.LastMutation("follow"); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if !last.Equal(now) {
+			t.Fatalf("expect last mutation at %v, got %v", now, last)
+		}
+	})
+}
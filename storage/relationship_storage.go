@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+const (
+	createRelationshipTableQuery = `
+		CREATE TABLE IF NOT EXISTS relationships (
+			author_id INTEGER PRIMARY KEY,
+			relation TEXT,
+			mutuals BOOLEAN DEFAULT FALSE,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(author_id) REFERENCES authors(id)
+		);
+	`
+
+	upsertRelationshipQuery = `
+		INSERT INTO relationships (author_id, relation, mutuals, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(author_id) DO UPDATE SET relation=excluded.relation, mutuals=excluded.mutuals, updated_at=excluded.updated_at;
+	`
+
+	selectRelationshipByAuthorQuery = `
+		SELECT author_id, relation, mutuals, updated_at FROM relationships WHERE author_id = ?;
+	`
+)
+
+type RelationshipStorage struct {
+	db *sql.DB
+}
+
+func NewRelationshipStorage(db *sql.DB) *RelationshipStorage {
+	return &RelationshipStorage{db: db}
+}
+
+func (rs *RelationshipStorage) CreateTable() error {
+	_, err := rs.db.Exec(createRelationshipTableQuery)
+	return err
+}
+
+func (rs *RelationshipStorage) Upsert(relationship *domain.Relationship) (*domain.Relationship, error) {
+	if _, err := rs.db.Exec(upsertRelationshipQuery, relationship.AuthorId, relationship.Relation, relationship.Mutuals, time.Now()); err != nil {
+		return nil, err
+	}
+	return rs.GetByAuthor(relationship.AuthorId)
+}
+
+func (rs *RelationshipStorage) GetByAuthor(authorId uint64) (*domain.Relationship, error) {
+	var relationship domain.Relationship
+	err := rs.db.QueryRow(selectRelationshipByAuthorQuery, authorId).
+		Scan(&relationship.AuthorId, &relationship.Relation, &relationship.Mutuals, &relationship.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
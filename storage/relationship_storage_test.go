@@ -0,0 +1,49 @@
+package storage_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestRelationshipStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	relationshipStorage := storage.NewRelationshipStorage(databse)
+	t.Run("create table", func(t *testing.T) {
+		if err := relationshipStorage.CreateTable(); err != nil {
+			t.Fatalf(err.Error())
+		}
+	})
+
+	t.Run("upsert relationship", func(t *testing.T) {
+		relationship, err := relationshipStorage.Upsert(&domain.Relationship{AuthorId: 1, Relation: domain.RelationFollowing})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if relationship.Relation != domain.RelationFollowing {
+			t.Fatalf("Relation error, expect %s, got %s", domain.RelationFollowing, relationship.Relation)
+		}
+
+		relationship, err = relationshipStorage.Upsert(&domain.Relationship{AuthorId: 1, Relation: domain.RelationInvitationPending})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if relationship.Relation != domain.RelationInvitationPending {
+			t.Fatalf("Relation error, expect %s, got %s", domain.RelationInvitationPending, relationship.Relation)
+		}
+	})
+
+	t.Run("get by unknown author", func(t *testing.T) {
+		if _, err := relationshipStorage.GetByAuthor(2); !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("expected no rows, got %v", err)
+		}
+	})
+}
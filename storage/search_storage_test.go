@@ -0,0 +1,67 @@
+package storage_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/storage"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestJobStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	jobStorage := storage.NewJobStorage(databse)
+	if err := jobStorage.CreateTable(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("upsert job", func(t *testing.T) {
+		job, err := jobStorage.Upsert(&domain.Job{JobId: "1", Title: "Golang Engineer"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		job, err = jobStorage.Upsert(&domain.Job{JobId: "1", Title: "Senior Golang Engineer"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if job.ID != 1 {
+			t.Fatalf("Job shoud be using id 1")
+		}
+		if job.Title != "Senior Golang Engineer" {
+			t.Fatalf("Job title error, expect %s, got %s", "Senior Golang Engineer", job.Title)
+		}
+	})
+}
+
+func TestCompanyStorage(t *testing.T) {
+	databse, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	companyStorage := storage.NewCompanyStorage(databse)
+	if err := companyStorage.CreateTable(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	t.Run("upsert company", func(t *testing.T) {
+		company, err := companyStorage.Upsert(&domain.Company{Url: "company-url", Name: "Acme"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		company, err = companyStorage.Upsert(&domain.Company{Url: "company-url", Name: "Acme Corp", Followers: "12K followers"})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if company.ID != 1 {
+			t.Fatalf("Company shoud be using id 1")
+		}
+		if company.Name != "Acme Corp" || company.Followers != "12K followers" {
+			t.Fatalf("unexpected company %v", company)
+		}
+	})
+}
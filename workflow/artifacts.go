@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdplog "github.com/chromedp/cdproto/log"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	artifactsTimeout  = 15 * time.Second
+	maxConsoleEntries = 1000
+	screenshotFile    = "screenshot.png"
+	htmlFile          = "page.html"
+	urlFile           = "url.txt"
+	consoleFile       = "console.log"
+	errorFile         = "error.txt"
+)
+
+// artifactsDir is where failure artifacts are saved, empty disable them
+var artifactsDir string
+
+// SetArtifactsDir define where failure artifacts of workflow tasks are saved
+func SetArtifactsDir(dir string) {
+	artifactsDir = dir
+}
+
+// FailureError is a failed workflow with the directory of his artifacts
+type FailureError struct {
+	Dir string
+	Err error
+}
+
+func (e *FailureError) Error() string {
+	return fmt.Sprintf("%v (screenshot, html, url and console log saved in %s)", e.Err, e.Dir)
+}
+
+func (e *FailureError) Unwrap() error {
+	return e.Err
+}
+
+type consoleKey struct{}
+
+// consoleLog keep the last console messages of a browser tab
+type consoleLog struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (c *consoleLog) add(level, text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, fmt.Sprintf("%s [%s] %s", time.Now().Format(time.RFC3339), level, text))
+	if len(c.entries) > maxConsoleEntries {
+		c.entries = c.entries[len(c.entries)-maxConsoleEntries:]
+	}
+}
+
+func (c *consoleLog) lines() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.entries...)
+}
+
+// WatchConsole start recording the browser console of chromedp context, saved
+// with failure artifacts, it should be called before the first task runs
+func WatchConsole(ctx context.Context) context.Context {
+	console := &consoleLog{}
+	chromedp.ListenTarget(ctx, func(ev any) {
+		switch ev := ev.(type) {
+		case *runtime.EventConsoleAPICalled:
+			args := make([]string, 0, len(ev.Args))
+			for _, arg := range ev.Args {
+				args = append(args, remoteObjectText(arg))
+			}
+			console.add(string(ev.Type), strings.Join(args, " "))
+		case *runtime.EventExceptionThrown:
+			text := ev.ExceptionDetails.Text
+			if ev.ExceptionDetails.Exception != nil {
+				text += " " + ev.ExceptionDetails.Exception.Description
+			}
+			console.add("exception", text)
+		case *cdplog.EventEntryAdded:
+			console.add(string(ev.Entry.Level), strings.TrimSpace(ev.Entry.Text+" "+ev.Entry.URL))
+		}
+	})
+	return context.WithValue(ctx, consoleKey{}, console)
+}
+
+func remoteObjectText(arg *runtime.RemoteObject) string {
+	if len(arg.Value) > 0 {
+		return strings.Trim(string(arg.Value), `"`)
+	}
+	if arg.Description != "" {
+		return arg.Description
+	}
+	return string(arg.Type)
+}
+
+// Run execute actions like chromedp.Run, saving a screenshot, html, url and console
+// log of page when they fail
+func Run(ctx context.Context, actions ...chromedp.Action) error {
+	err := chromedp.Run(ctx, actions...)
+	if err == nil || artifactsDir == "" {
+		return err
+	}
+	var failure *FailureError
+	if errors.As(err, &failure) {
+		return err
+	}
+	dir, saveErr := captureArtifacts(ctx, err)
+	if saveErr != nil {
+		return fmt.Errorf("%w (failed to save artifacts: %v)", err, saveErr)
+	}
+	return &FailureError{Dir: dir, Err: err}
+}
+
+// artifacts is the page state when a workflow fail
+type artifacts struct {
+	screenshot []byte
+	html       string
+	url        string
+	console    []string
+	errors     []error
+}
+
+// captureArtifacts read page state using a fresh context, because ctx can already be expired
+func captureArtifacts(ctx context.Context, failure error) (string, error) {
+	result := artifacts{errors: []error{failure}}
+	if console, ok := ctx.Value(consoleKey{}).(*consoleLog); ok {
+		result.console = console.lines()
+	}
+
+	if c := chromedp.FromContext(ctx); c != nil && c.Target != nil {
+		captureCtx, cancel := context.WithTimeout(context.Background(), artifactsTimeout)
+		defer cancel()
+		captureCtx = cdp.WithExecutor(captureCtx, c.Target)
+		steps := []chromedp.Action{
+			chromedp.Location(&result.url),
+			chromedp.Evaluate(`document.documentElement.outerHTML`, &result.html),
+			chromedp.FullScreenshot(&result.screenshot, 100),
+		}
+		for _, step := range steps {
+			if err := step.Do(captureCtx); err != nil {
+				result.errors = append(result.errors, fmt.Errorf("capture failed: %w", err))
+			}
+		}
+	}
+
+	dir := filepath.Join(artifactsDir, time.Now().Format("20060102-150405.000"))
+	return dir, result.save(dir)
+}
+
+// save write each artifact in dir, skipping the empty ones
+func (a artifacts) save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		screenshotFile: a.screenshot,
+		htmlFile:       []byte(a.html),
+		urlFile:        []byte(a.url),
+		consoleFile:    []byte(strings.Join(a.console, "\n")),
+	}
+	var errorsText []string
+	for _, err := range a.errors {
+		errorsText = append(errorsText, err.Error())
+	}
+	files[errorFile] = []byte(strings.Join(errorsText, "\n"))
+
+	for name, content := range files {
+		if len(content) == 0 {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
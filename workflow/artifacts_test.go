@@ -0,0 +1,62 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArtifactsSave(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "failure")
+	result := artifacts{
+		html:    "<html><body>feed</body></html>",
+		url:     "https://www.linkedin.com/feed/",
+		console: []string{"first", "second"},
+		errors:  []error{context.DeadlineExceeded},
+	}
+	if err := result.save(dir); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := map[string]string{
+		htmlFile:    result.html,
+		urlFile:     result.url,
+		consoleFile: "first\nsecond",
+		errorFile:   context.DeadlineExceeded.Error(),
+	}
+	for name, content := range expected {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if string(got) != content {
+			t.Fatalf("expect %s with %q, got %q", name, content, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, screenshotFile)); !os.IsNotExist(err) {
+		t.Fatalf("expect no screenshot file when capture fail")
+	}
+}
+
+func TestFailureError(t *testing.T) {
+	err := error(&FailureError{Dir: "/tmp/artifacts/20240101-000000.000", Err: context.DeadlineExceeded})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expect failure to unwrap original error")
+	}
+	if !strings.Contains(err.Error(), "/tmp/artifacts/20240101-000000.000") {
+		t.Fatalf("expect artifacts dir in error, got %s", err.Error())
+	}
+}
+
+func TestConsoleLogLimit(t *testing.T) {
+	console := &consoleLog{}
+	for i := 0; i < maxConsoleEntries+10; i++ {
+		console.add("log", "message")
+	}
+	if len(console.lines()) != maxConsoleEntries {
+		t.Fatalf("expect %d console entries, got %d", maxConsoleEntries, len(console.lines()))
+	}
+}
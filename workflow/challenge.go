@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+const (
+	checkpointPath    = "/checkpoint/"
+	maxChallengeTries = 3
+	challengeWait     = 10 * time.Second
+)
+
+// ChallengeKind is the kind of security check linkedin show after submit credentials
+type ChallengeKind string
+
+const (
+	ChallengeSecurityVerification ChallengeKind = "security verification"
+	ChallengeEmailPin             ChallengeKind = "email pin"
+	ChallengeAuthenticator        ChallengeKind = "authenticator code"
+	ChallengeCaptcha              ChallengeKind = "captcha"
+)
+
+// ChallengeError is returned by Auth when a challenge can not be completed
+type ChallengeError struct {
+	Kind ChallengeKind
+	Url  string
+	Err  error
+}
+
+func (e *ChallengeError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("login blocked by %s challenge at %s: %v", e.Kind, e.Url, e.Err)
+	}
+	return fmt.Sprintf("login blocked by %s challenge at %s", e.Kind, e.Url)
+}
+
+func (e *ChallengeError) Unwrap() error {
+	return e.Err
+}
+
+// ChallengeResolver returns the code for pin challenges, for challenges without code
+// (captcha or app approval) it should return after user complete it in browser
+type ChallengeResolver func(kind ChallengeKind) (string, error)
+
+// waitLogin wait for the feed after submit credentials, solving challenges with resolver
+func waitLogin(resolver ChallengeResolver) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		tries := 0
+		for {
+			logged, err := exists(ctx, sel(search_sel))
+			if err != nil {
+				return err
+			}
+			if logged {
+				return nil
+			}
+
+			kind, input, err := detectChallenge(ctx)
+			if err != nil {
+				return err
+			}
+			if kind != "" {
+				var location string
+				if err := chromedp.Location(&location).Do(ctx); err != nil {
+					return err
+				}
+				if resolver == nil {
+					return &ChallengeError{Kind: kind, Url: location}
+				}
+				if tries >= maxChallengeTries {
+					return &ChallengeError{Kind: kind, Url: location, Err: fmt.Errorf("not solved after %d tries", tries)}
+				}
+				tries++
+				code, err := resolver(kind)
+				if err != nil {
+					return &ChallengeError{Kind: kind, Url: location, Err: err}
+				}
+				if input != "" {
+					if err := chromedp.Run(ctx,
+						sendKeys(input, code),
+						click(sel(pin_submit_sel)),
+					); err != nil {
+						return err
+					}
+					// give linkedin time to leave the challenge page before check it again
+					waitCtx, cancel := context.WithTimeout(ctx, challengeWait)
+					chromedp.WaitNotPresent(input).Do(waitCtx)
+					cancel()
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+}
+
+// detectChallenge returns the challenge in current page and the input to fill his code, if any
+func detectChallenge(ctx context.Context) (ChallengeKind, string, error) {
+	checks := []struct {
+		kind     ChallengeKind
+		selector selectors.Selector
+		input    bool
+	}{
+		{ChallengeEmailPin, sel(email_pin_sel), true},
+		{ChallengeAuthenticator, sel(authenticator_sel), true},
+		{ChallengeCaptcha, sel(captcha_sel), false},
+	}
+	for _, check := range checks {
+		found, err := match(ctx, check.selector)
+		if err != nil {
+			return "", "", err
+		}
+		if found != "" {
+			if check.input {
+				return check.kind, found, nil
+			}
+			return check.kind, "", nil
+		}
+	}
+
+	var location string
+	if err := chromedp.Location(&location).Do(ctx); err != nil {
+		return "", "", err
+	}
+	if strings.Contains(location, checkpointPath) {
+		return ChallengeSecurityVerification, "", nil
+	}
+	return "", "", nil
+}
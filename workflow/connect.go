@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chromedp/chromedp"
+	"github.com/victorfernandesraton/lazydin/domain"
+)
+
+var ErrInvitationPending = errors.New("failed to connect user, invitation alredy pending")
+
+// SendConnectionRequest send a connection invitation to user from his profile page,
+// looking for Connect inside More menu when it is not a main action, a empty note
+// send the invitation without note
+func SendConnectionRequest(ctx context.Context, note string) error {
+	if err := domain.ValidateConnectionNote(note); err != nil {
+		return err
+	}
+	buttons, err := profileActions(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := buttons["Pending"]; ok {
+		return ErrInvitationPending
+	}
+
+	if err := Run(ctx, mutation(ActionConnect)); err != nil {
+		return err
+	}
+	if btnConnect, ok := buttons["Connect"]; ok {
+		err = Run(ctx, step("SendConnectionRequest: clicking Connect", StepClick, click(btnConnect.FullXPath())))
+	} else if btnMore, ok := buttons["More"]; ok {
+		err = Run(ctx,
+			step("SendConnectionRequest: opening More menu", StepClick, click(btnMore.FullXPath())),
+			step("SendConnectionRequest: waiting for Connect in More menu", StepWait, waitVisible(sel(more_connect_sel))),
+			step("SendConnectionRequest: clicking Connect in More menu", StepClick, click(sel(more_connect_sel))),
+		)
+	} else {
+		return fmt.Errorf("failed to connect user, not found Connect button")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := Run(ctx,
+		step("SendConnectionRequest: waiting for invitation dialog", StepWait, waitVisible(sel(invite_dialog_sel))),
+		answerHowYouKnow(),
+	); err != nil {
+		return err
+	}
+
+	if note == "" {
+		return Run(ctx,
+			step("SendConnectionRequest: waiting for send without note", StepWait, waitVisible(sel(invite_without_note_sel))),
+			step("SendConnectionRequest: sending without note", StepClick, click(sel(invite_without_note_sel))),
+			step("SendConnectionRequest: waiting invitation dialog to close", StepWait, waitNotPresent(sel(invite_dialog_sel))),
+		)
+	}
+	return Run(ctx,
+		step("SendConnectionRequest: waiting for add note", StepWait, waitVisible(sel(invite_add_note_sel))),
+		step("SendConnectionRequest: clicking add note", StepClick, click(sel(invite_add_note_sel))),
+		step("SendConnectionRequest: waiting for note input", StepWait, waitVisible(sel(invite_note_sel))),
+		step("SendConnectionRequest: typing note", StepType, sendKeys(sel(invite_note_sel), note)),
+		step("SendConnectionRequest: waiting for send button", StepWait, waitEnabled(sel(invite_send_sel))),
+		step("SendConnectionRequest: sending invitation", StepClick, click(sel(invite_send_sel))),
+		step("SendConnectionRequest: waiting invitation dialog to close", StepWait, waitNotPresent(sel(invite_dialog_sel))),
+	)
+}
+
+// answerHowYouKnow choose Other when linkedin ask how you know the user before connect
+func answerHowYouKnow() chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		asked, err := exists(ctx, sel(how_you_know_other_sel))
+		if err != nil || !asked {
+			return err
+		}
+		return chromedp.Run(ctx,
+			step("SendConnectionRequest: answering how you know", StepClick, click(sel(how_you_know_other_sel))),
+			step("SendConnectionRequest: waiting for Connect in how you know", StepWait, waitEnabled(sel(how_you_know_connect_sel))),
+			step("SendConnectionRequest: clicking Connect in how you know", StepClick, click(sel(how_you_know_connect_sel))),
+		)
+	}
+}
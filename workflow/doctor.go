@@ -0,0 +1,49 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+// SelectorCheck is the result of looking for a selector in current page
+type SelectorCheck struct {
+	Key string
+	// Fallback is the first fallback matching some node, empty when none match
+	Fallback string
+	Count    int
+}
+
+func (c SelectorCheck) Found() bool {
+	return c.Count > 0
+}
+
+// CheckSelectors look for each selector key in current page without waiting for them
+func CheckSelectors(ctx context.Context, keys []string) ([]SelectorCheck, error) {
+	checks := make([]SelectorCheck, 0, len(keys))
+	for _, key := range keys {
+		check := SelectorCheck{Key: key}
+		for _, fallback := range selectors.Get(key).Fallbacks {
+			var nodes []*cdp.Node
+			if err := chromedp.Nodes(fallback, &nodes, chromedp.AtLeast(0)).Do(ctx); err != nil {
+				return nil, err
+			}
+			if len(nodes) > 0 {
+				check.Fallback = fallback
+				check.Count = len(nodes)
+				break
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// OpenPage open a url as is, like a saved html file, without linkedin base url
+func OpenPage(url string) chromedp.Tasks {
+	return chromedp.Tasks{
+		navigate(url),
+	}
+}
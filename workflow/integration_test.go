@@ -0,0 +1,163 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/victorfernandesraton/lazydin/adapters"
+	"github.com/victorfernandesraton/lazydin/browser"
+	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/mocklinkedin"
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+// newMockBrowser start headless chrome pointing workflows to a mock linkedin,
+// skipping the test when chrome is not installed
+func newMockBrowser(t *testing.T) (context.Context, *mocklinkedin.Server) {
+	t.Helper()
+	found := false
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "headless-shell"} {
+		if _, err := exec.LookPath(name); err == nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Skip("chrome not found, skipping integration test")
+	}
+
+	post, err := os.ReadFile(filepath.Join("..", "testdata", "output.html"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	server := mocklinkedin.New(string(post))
+	t.Cleanup(server.Close)
+
+	workflow.SetBaseUrl(server.URL)
+	t.Cleanup(func() { workflow.SetBaseUrl(workflow.DefaultBaseUrl) })
+
+	options := browser.DefaultBrowserOptions()
+	options.Headless = true
+	ctx, cancelBrowser := browser.NewContext(options)
+	ctx, cancelTimeout := context.WithTimeout(workflow.WatchConsole(ctx), time.Minute)
+	t.Cleanup(func() {
+		cancelTimeout()
+		cancelBrowser()
+	})
+	return ctx, server
+}
+
+func TestIntegrationLogin(t *testing.T) {
+	ctx, server := newMockBrowser(t)
+
+	if err := chromedp.Run(ctx, workflow.Login("user@mail.com", "secret", nil)); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !server.HasAction(mocklinkedin.ActionLogin, "user@mail.com") {
+		t.Fatalf("expect login recorded, got %v", server.Actions())
+	}
+
+	logged, err := workflow.IsLoggedIn(ctx)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !logged {
+		t.Fatalf("expect session to be reused after login")
+	}
+}
+
+func TestIntegrationSearchPosts(t *testing.T) {
+	ctx, server := newMockBrowser(t)
+
+	if err := chromedp.Run(ctx,
+		workflow.Login("user@mail.com", "secret", nil),
+		workflow.SearchForPosts(workflow.PostSearch{Query: "golang"}),
+	); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !server.HasAction(mocklinkedin.ActionSearch, "golang") {
+		t.Fatalf("expect search recorded, got %v", server.Actions())
+	}
+
+	content, err := workflow.LoadPosts(ctx, 0, 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := adapters.ExtractContent(content)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(result) != 1 {
+		t.Fatalf("expect 1 post, got %d", len(result))
+	}
+	if result[0].Author.Url == "" {
+		t.Fatalf("expect author url extracted from post")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !server.HasAction(mocklinkedin.ActionSeeMore, "urn:li:activity:7151313167762010113") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expect see more clicked before extracting posts, got %v", server.Actions())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestIntegrationFollow(t *testing.T) {
+	ctx, server := newMockBrowser(t)
+
+	// stored urls point to linkedin and must be opened in base url
+	author := domain.Author{Url: "https://www.linkedin.com/in/john-doe/"}
+	if err := chromedp.Run(ctx,
+		workflow.Login("user@mail.com", "secret", nil),
+		workflow.GoToUserPage(author),
+	); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := workflow.ExecuteFollowAction(ctx, "Follow"); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !server.HasAction("Follow", "/in/john-doe/") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expect follow click recorded, got %v", server.Actions())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestIntegrationFailureArtifacts(t *testing.T) {
+	ctx, _ := newMockBrowser(t)
+	dir := t.TempDir()
+	workflow.SetArtifactsDir(dir)
+	defer workflow.SetArtifactsDir("")
+
+	if err := workflow.Run(ctx, workflow.Login("user@mail.com", "secret", nil)); err != nil {
+		t.Fatalf(err.Error())
+	}
+	// there is no comment button in mock profile page, so it wait until timeout
+	waitCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	err := workflow.Run(waitCtx, workflow.CommentOnPost(domain.Post{Urn: "urn:li:activity:1"}, "hello"))
+
+	var failure *workflow.FailureError
+	if !errors.As(err, &failure) {
+		t.Fatalf("expect failure with artifacts, got %v", err)
+	}
+	if !strings.HasPrefix(failure.Dir, dir) {
+		t.Fatalf("expect artifacts in %s, got %s", dir, failure.Dir)
+	}
+	for _, name := range []string{"screenshot.png", "page.html", "url.txt", "error.txt"} {
+		if _, err := os.Stat(filepath.Join(failure.Dir, name)); err != nil {
+			t.Fatalf("expect %s in artifacts: %v", name, err)
+		}
+	}
+}
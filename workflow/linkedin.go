@@ -2,60 +2,124 @@ package workflow
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/chromedp"
-	"github.com/chromedp/chromedp/kb"
 	"github.com/victorfernandesraton/lazydin/domain"
+	"github.com/victorfernandesraton/lazydin/selectors"
 )
 
 const (
-	linkedinDomain          = "https://linkedin.com"
-	login                   = "https://linkedin.com/login"
-	linkedinFeed            = "https://www.linkedin.com/feed"
-	username_xpath          = "//input[@id='username']"
-	password_xpath          = "//input[@id='password']"
-	submit_xpath            = "//button[@type='submit']"
-	search_xpath            = "//input[@placeholder='Search']"
-	search_qs               = "#global-nav-typeahead > input"
-	button_posts            = "//nav/*/ul/li/button[text() = 'Posts']"
-	post_xpath              = "//ul[@role='list' and contains(@class, 'reusable-search__entity-result-list')]/li"
-	profileActionButtons_qs = "main button.pvs-profile-actions__action span"
+	DefaultBaseUrl      = "https://www.linkedin.com"
+	loginPath           = "/login"
+	feedPath            = "/feed"
+	postPath            = "/feed/update/"
+	searchPath          = "/search/results"
+	jobsPath            = "/jobs/search/"
+	pollInterval        = 500 * time.Millisecond
+	sessionCheckTimeout = 15 * time.Second
 )
 
-func Auth(username, password string) chromedp.Tasks {
+// baseUrl is where linkedin pages are opened, it can point to a local server serving saved pages
+var baseUrl = DefaultBaseUrl
+
+var ErrMessageUnavailable = errors.New("message button not available for this user")
+
+// SetBaseUrl define the linkedin address used by every workflow task
+func SetBaseUrl(url string) {
+	baseUrl = strings.TrimSuffix(url, "/")
+}
+
+func linkedinUrl(path string) string {
+	return baseUrl + path
+}
+
+func Auth(username, password string, resolver ChallengeResolver) chromedp.Tasks {
 	return chromedp.Tasks{
-		chromedp.Navigate(linkedinDomain),
-		chromedp.Navigate(login),
-		chromedp.WaitVisible(username_xpath),
-		chromedp.SendKeys(username_xpath, username),
-		chromedp.WaitVisible(password_xpath),
-		chromedp.SendKeys(password_xpath, password),
-		chromedp.Click(submit_xpath),
-		chromedp.WaitVisible(search_xpath),
+		step("Auth: opening linkedin", StepNavigate, navigate(linkedinUrl(""))),
+		step("Auth: opening login page", StepNavigate, navigate(linkedinUrl(loginPath))),
+		step("Auth: waiting for username input", StepWait, waitVisible(sel(username_sel))),
+		step("Auth: typing username", StepType, sendKeys(sel(username_sel), username)),
+		step("Auth: waiting for password input", StepWait, waitVisible(sel(password_sel))),
+		step("Auth: typing password", StepType, sendKeys(sel(password_sel), password)),
+		step("Auth: submitting credentials", StepClick, click(sel(submit_sel))),
+		step("Auth: waiting for feed", StepLogin, waitLogin(resolver)),
 	}
 }
 
-func SearchForPosts(query string) chromedp.Tasks {
+// Login reuse the browser session when it is still valid, running Auth only when it expired
+func Login(username, password string, resolver ChallengeResolver) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		logged, err := IsLoggedIn(ctx)
+		if err != nil {
+			return err
+		}
+		if logged {
+			return nil
+		}
+		return Auth(username, password, resolver).Do(ctx)
+	}
+}
+
+// IsLoggedIn open the feed and check if linkedin keep the user there or redirect to login
+func IsLoggedIn(ctx context.Context) (bool, error) {
+	if err := step("IsLoggedIn: opening feed", StepNavigate, navigate(linkedinUrl(feedPath))).Do(ctx); err != nil {
+		return false, err
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, sessionCheckTimeout)
+	defer cancel()
+	for {
+		if found, err := exists(checkCtx, sel(search_sel)); err != nil || found {
+			return found, err
+		}
+		if found, err := exists(checkCtx, sel(username_sel)); err != nil || found {
+			return false, err
+		}
+		select {
+		case <-checkCtx.Done():
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			return false, nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func SearchForPosts(search PostSearch) chromedp.Tasks {
 	return chromedp.Tasks{
-		chromedp.Navigate(linkedinFeed),
-		chromedp.SendKeys(search_xpath, query),
-		chromedp.KeyEvent(kb.Enter),
-		chromedp.WaitVisible(button_posts),
-		chromedp.Click(button_posts),
-		chromedp.WaitVisible(post_xpath),
+		step("SearchForPosts: opening search", StepNavigate, navigate(search.Url())),
+		step("SearchForPosts: waiting for posts", StepWait, waitVisible(sel(post_sel))),
 	}
 }
+
+// ExpandPosts click every see more button in search results, so posts html has their whole text
+func ExpandPosts() chromedp.ActionFunc {
+	return step("ExpandPosts: clicking see more", StepClick, clickAll(sel(see_more_sel)))
+}
+
 func ExtractOuterHTML(ctx context.Context) (outerHTML []string, err error) {
-	var nodes []*cdp.Node
-	if err := chromedp.Run(ctx, chromedp.Nodes(post_xpath, &nodes, chromedp.BySearch)); err != nil {
+	if err := Run(ctx, ExpandPosts()); err != nil {
+		return nil, err
+	}
+	return extractOuterHTML(ctx, sel(post_sel))
+}
+
+func extractOuterHTML(ctx context.Context, selector selectors.Selector) (outerHTML []string, err error) {
+	nodes, err := findNodes(ctx, "waiting for "+selector.Key, selector)
+	if err != nil {
 		return nil, err
 	}
 
 	for _, node := range nodes {
 		var html string
-		if err := chromedp.Run(ctx, chromedp.OuterHTML(node.FullXPath(), &html)); err != nil {
+		if err := Run(ctx, chromedp.OuterHTML(node.FullXPath(), &html)); err != nil {
 			return nil, err
 		}
 		outerHTML = append(outerHTML, html)
@@ -65,33 +129,50 @@ func ExtractOuterHTML(ctx context.Context) (outerHTML []string, err error) {
 
 func GoToUserPage(user domain.Author) chromedp.Tasks {
 	return chromedp.Tasks{
-		chromedp.Navigate(user.Url),
+		step("GoToUserPage: opening profile", StepNavigate, navigate(profileUrl(user.Url))),
 	}
 }
 
-func ExtractPriofileActions(ctx context.Context) ([]*cdp.Node, error) {
-	var nodes []*cdp.Node
-	if err := chromedp.Run(ctx, chromedp.Nodes(profileActionButtons_qs, &nodes, chromedp.BySearch)); err != nil {
-		return nil, err
+// profileUrl point stored linkedin profile urls to baseUrl, keeping other addresses unchanged
+func profileUrl(ref string) string {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	if parsed.Host == "linkedin.com" || strings.HasSuffix(parsed.Host, ".linkedin.com") {
+		return linkedinUrl(parsed.Path)
 	}
-	return nodes, nil
+	return ref
+}
+
+func ExtractPriofileActions(ctx context.Context) ([]*cdp.Node, error) {
+	return findNodes(ctx, "ExtractProfileActions: waiting for action buttons", sel(profileActionButtons_sel))
 
 }
 
-func ExecuteFollowAction(ctx context.Context, selectedAction string) error {
+// profileActions map each action label in profile page to his button node
+func profileActions(ctx context.Context) (map[string]*cdp.Node, error) {
 	buttons := make(map[string]*cdp.Node)
 	nodes, err := ExtractPriofileActions(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	for _, node := range nodes {
 		var text string
-		if err := chromedp.Run(ctx, chromedp.Text(node.FullXPath(), &text)); err != nil {
-			return err
+		if err := Run(ctx, chromedp.Text(node.FullXPath(), &text)); err != nil {
+			return nil, err
 		}
 
 		buttons[text] = node.Parent
 	}
+	return buttons, nil
+}
+
+func ExecuteFollowAction(ctx context.Context, selectedAction string) error {
+	buttons, err := profileActions(ctx)
+	if err != nil {
+		return err
+	}
 	btnFollow, ok := buttons[selectedAction]
 
 	if !ok {
@@ -106,9 +187,74 @@ func ExecuteFollowAction(ctx context.Context, selectedAction string) error {
 		return fmt.Errorf("failed to follow user, not found %s button", selectedAction)
 
 	}
-	chromedp.Run(ctx,
-		chromedp.Click(btnFollow.FullXPath()),
+	return Run(ctx,
+		mutation(ActionFollow),
+		step("ExecuteFollowAction: clicking "+selectedAction, StepClick, click(btnFollow.FullXPath())),
+	)
+}
+
+// SendMessage send a direct message to user from his profile page
+func SendMessage(ctx context.Context, text string) error {
+	buttons, err := profileActions(ctx)
+	if err != nil {
+		return err
+	}
+	btnMessage, ok := buttons["Message"]
+	if !ok {
+		return ErrMessageUnavailable
+	}
+
+	return Run(ctx,
+		mutation(ActionMessage),
+		step("SendMessage: opening message box", StepClick, click(btnMessage.FullXPath())),
+		step("SendMessage: waiting for message box", StepWait, waitVisible(sel(message_box_sel))),
+		step("SendMessage: typing message", StepType, sendKeys(sel(message_box_sel), text)),
+		step("SendMessage: waiting for send button", StepWait, waitEnabled(sel(message_send_sel))),
+		step("SendMessage: sending message", StepClick, click(sel(message_send_sel))),
+		step("SendMessage: waiting for sent message", StepWait, waitForText(sel(message_item_sel), text)),
 	)
+}
+
+func CommentOnPost(post domain.Post, text string) chromedp.Tasks {
+	return chromedp.Tasks{
+		step("CommentOnPost: opening post", StepNavigate, navigate(linkedinUrl(postPath+post.Urn.String()+"/"))),
+		step("CommentOnPost: waiting for comment button", StepWait, waitVisible(sel(comment_button_sel))),
+		step("CommentOnPost: opening comment box", StepClick, click(sel(comment_button_sel))),
+		step("CommentOnPost: waiting for comment box", StepWait, waitVisible(sel(comment_box_sel))),
+		step("CommentOnPost: typing comment", StepType, sendKeys(sel(comment_box_sel), text)),
+		step("CommentOnPost: waiting for submit button", StepWait, waitEnabled(sel(comment_submit_sel))),
+		mutation(ActionComment),
+		step("CommentOnPost: submitting comment", StepClick, click(sel(comment_submit_sel))),
+		step("CommentOnPost: waiting for posted comment", StepWait, waitForText(sel(comment_item_sel), text)),
+	}
+}
 
-	return nil
+// waitForText block until some element matching selector contains the first line of text
+func waitForText(selector selectors.Selector, text string) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		firstLine := strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+		encodedText, err := json.Marshal(firstLine)
+		if err != nil {
+			return err
+		}
+		encodedSelectors, err := json.Marshal(selector.Fallbacks)
+		if err != nil {
+			return err
+		}
+		expression := fmt.Sprintf(findTextJS, encodedSelectors, encodedText)
+		for {
+			var found bool
+			if err := chromedp.Evaluate(expression, &found).Do(ctx); err != nil {
+				return err
+			}
+			if found {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("text not found in %s: %w", selector, ctx.Err())
+			case <-time.After(pollInterval):
+			}
+		}
+	}
 }
@@ -0,0 +1,20 @@
+package workflow
+
+import "testing"
+
+func TestProfileUrl(t *testing.T) {
+	SetBaseUrl("http://127.0.0.1:8080/")
+	t.Cleanup(func() { SetBaseUrl(DefaultBaseUrl) })
+
+	cases := map[string]string{
+		"https://www.linkedin.com/in/john-doe/":                  "http://127.0.0.1:8080/in/john-doe/",
+		"https://br.linkedin.com/in/john-doe?miniProfileUrn=urn": "http://127.0.0.1:8080/in/john-doe",
+		"http://127.0.0.1:9090/in/john-doe/":                     "http://127.0.0.1:9090/in/john-doe/",
+		"https://www.notlinkedin.com/in/john-doe/":               "https://www.notlinkedin.com/in/john-doe/",
+	}
+	for ref, expected := range cases {
+		if got := profileUrl(ref); got != expected {
+			t.Fatalf("expect %s for %s, got %s", expected, ref, got)
+		}
+	}
+}
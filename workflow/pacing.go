@@ -0,0 +1,181 @@
+package workflow
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Action is a kind of browser action paced to look like a human using linkedin
+type Action string
+
+const (
+	ActionTyping   Action = "typing"
+	ActionNavigate Action = "navigate"
+	ActionClick    Action = "click"
+	// mutating actions, their delay is the minimum interval between two of them
+	ActionFollow  Action = "follow"
+	ActionConnect Action = "connect"
+	ActionComment Action = "comment"
+	ActionMessage Action = "message"
+)
+
+// Delay is a random duration between Min and Max
+type Delay struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Pacing holds the delay of each action, actions without delay run without pause
+type Pacing map[Action]Delay
+
+// MutationLog keep when each mutating action was done last, a persistent log
+// keep the minimum interval between separated runs of lazydin
+type MutationLog interface {
+	// LastMutation returns zero time when action was never done
+	LastMutation(action string) (time.Time, error)
+	SetLastMutation(action string, at time.Time) error
+}
+
+// memoryLog is the mutation log of a single run
+type memoryLog struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func (m *memoryLog) LastMutation(action string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last[action], nil
+}
+
+func (m *memoryLog) SetLastMutation(action string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.last[action] = at
+	return nil
+}
+
+type pacer struct {
+	pacing    Pacing
+	mutations MutationLog
+}
+
+var pace = newPacer(Pacing{})
+
+func newPacer(pacing Pacing) *pacer {
+	return &pacer{pacing: pacing, mutations: &memoryLog{last: make(map[string]time.Time)}}
+}
+
+// SetPacing define delays used by every workflow task
+func SetPacing(pacing Pacing) {
+	pace = newPacer(pacing)
+}
+
+// SetMutationLog define where last mutating actions are kept, call it after SetPacing
+func SetMutationLog(log MutationLog) {
+	pace.mutations = log
+}
+
+// delay returns a random duration for action inside his configured range
+func (p *pacer) delay(action Action) time.Duration {
+	d := p.pacing[action]
+	if d.Max <= d.Min {
+		return d.Min
+	}
+	return d.Min + time.Duration(rand.Int63n(int64(d.Max-d.Min)))
+}
+
+// pause sleep a random delay of action
+func (p *pacer) pause(ctx context.Context, action Action) error {
+	return sleep(ctx, p.delay(action))
+}
+
+// interval block until the minimum interval since last mutating action of same kind pass
+func (p *pacer) interval(ctx context.Context, action Action) error {
+	last, err := p.mutations.LastMutation(string(action))
+	if err != nil {
+		return err
+	}
+	if !last.IsZero() {
+		if err := sleep(ctx, time.Until(last.Add(p.delay(action)))); err != nil {
+			return err
+		}
+	}
+	return p.mutations.SetLastMutation(string(action), time.Now())
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// navigate open url after a navigation pause
+func navigate(url string) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		if err := pace.pause(ctx, ActionNavigate); err != nil {
+			return err
+		}
+		return chromedp.Navigate(url).Do(ctx)
+	}
+}
+
+// click element after a click pause, sel can be a registry selector or a plain one
+func click(sel any, opts ...chromedp.QueryOption) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		if err := pace.pause(ctx, ActionClick); err != nil {
+			return err
+		}
+		sel, err := query(ctx, sel)
+		if err != nil {
+			return err
+		}
+		return chromedp.Click(sel, opts...).Do(ctx)
+	}
+}
+
+// sendKeys focus element and type text one key at time using typing delay,
+// step timeout only limit focusing the element
+func sendKeys(sel any, text string, opts ...chromedp.QueryOption) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		if err := pace.pause(ctx, ActionClick); err != nil {
+			return err
+		}
+		sel, err := query(ctx, sel)
+		if err != nil {
+			return err
+		}
+		if err := chromedp.Focus(sel, opts...).Do(ctx); err != nil {
+			return err
+		}
+		ctx = withoutStepTimeout(ctx)
+		for _, r := range text {
+			if err := chromedp.KeyEvent(string(r)).Do(ctx); err != nil {
+				return err
+			}
+			if err := pace.pause(ctx, ActionTyping); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// mutation wait the minimum interval configured for a mutating action
+func mutation(action Action) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		return pace.interval(ctx, action)
+	}
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPacerDelay(t *testing.T) {
+	p := newPacer(Pacing{
+		ActionClick:  {Min: 10 * time.Millisecond, Max: 20 * time.Millisecond},
+		ActionTyping: {Min: 5 * time.Millisecond},
+	})
+	for i := 0; i < 100; i++ {
+		if d := p.delay(ActionClick); d < 10*time.Millisecond || d >= 20*time.Millisecond {
+			t.Fatalf("delay %s out of range", d)
+		}
+	}
+	if d := p.delay(ActionTyping); d != 5*time.Millisecond {
+		t.Fatalf("expect fixed delay, got %s", d)
+	}
+	if d := p.delay(ActionNavigate); d != 0 {
+		t.Fatalf("expect no delay for action without pacing, got %s", d)
+	}
+}
+
+func TestPacerInterval(t *testing.T) {
+	interval := 50 * time.Millisecond
+	p := newPacer(Pacing{ActionFollow: {Min: interval}})
+	ctx := context.Background()
+
+	start := time.Now()
+	if err := p.interval(ctx, ActionFollow); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if elapsed := time.Since(start); elapsed >= interval {
+		t.Fatalf("first action should not wait, waited %s", elapsed)
+	}
+	if err := p.interval(ctx, ActionFollow); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if elapsed := time.Since(start); elapsed < interval {
+		t.Fatalf("second action should wait %s, waited %s", interval, elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := p.interval(cancelled, ActionFollow); err == nil {
+		t.Fatalf("expected error with cancelled context")
+	}
+}
+
+func TestPacerIntervalBetweenRuns(t *testing.T) {
+	interval := 50 * time.Millisecond
+	log := &memoryLog{last: make(map[string]time.Time)}
+	if err := log.SetLastMutation(string(ActionFollow), time.Now()); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// a new run start with a fresh pacer reading the same log
+	p := newPacer(Pacing{ActionFollow: {Min: interval}})
+	p.mutations = log
+	start := time.Now()
+	if err := p.interval(context.Background(), ActionFollow); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if elapsed := time.Since(start); elapsed < interval/2 {
+		t.Fatalf("first action of run should wait interval since last run, waited %s", elapsed)
+	}
+}
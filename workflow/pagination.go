@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+const (
+	scrollToBottomJS = `window.scrollTo(0, document.body.scrollHeight)`
+	loadMoreTimeout  = 5 * time.Second
+)
+
+var urnAttrPattern = regexp.MustCompile(`data-(?:urn|chameleon-result-urn|occludable-job-id)="([^"]+)"`)
+
+// LoadPosts scroll search results collecting posts until reach limit, max pages
+// or no new post appears, a zero limit or max pages means no cap
+func LoadPosts(ctx context.Context, limit, maxPages int) ([]string, error) {
+	return loadResults(ctx, sel(post_sel), limit, maxPages, ExpandPosts())
+}
+
+// loadResults collect results matching selector, running prepare, when not nil, before extracting each page
+func loadResults(ctx context.Context, selector selectors.Selector, limit, maxPages int, prepare chromedp.Action) (results []string, err error) {
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		if prepare != nil {
+			if err := Run(ctx, prepare); err != nil {
+				return nil, err
+			}
+		}
+		batch, err := extractOuterHTML(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		newItems := 0
+		for _, html := range batch {
+			key := resultKey(html)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			newItems++
+			results = append(results, html)
+			if limit > 0 && len(results) >= limit {
+				return results, nil
+			}
+		}
+		if newItems == 0 || (maxPages > 0 && page >= maxPages) {
+			return results, nil
+		}
+		if err := loadMore(ctx, selector, len(batch)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// resultKey identify result by his urn, using the whole html when there is no urn
+func resultKey(html string) string {
+	if match := urnAttrPattern.FindStringSubmatch(html); match != nil {
+		return match[1]
+	}
+	return html
+}
+
+// loadMore scroll to the end of results and click in show more button, waiting
+// until the list grow or loadMoreTimeout pass
+func loadMore(ctx context.Context, selector selectors.Selector, current int) error {
+	if err := chromedp.Evaluate(scrollToBottomJS, nil).Do(ctx); err != nil {
+		return err
+	}
+	hasButton, err := exists(ctx, sel(show_more_results_sel))
+	if err != nil {
+		return err
+	}
+	if hasButton {
+		if err := step("loading more results", StepClick, click(sel(show_more_results_sel))).Do(ctx); err != nil {
+			return err
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, loadMoreTimeout)
+	defer cancel()
+	for {
+		found, err := match(waitCtx, selector)
+		if err != nil {
+			return err
+		}
+		var nodes []*cdp.Node
+		if found != "" {
+			err = chromedp.Nodes(found, &nodes, chromedp.AtLeast(0)).Do(waitCtx)
+		}
+		if waitCtx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+		if len(nodes) > current {
+			return nil
+		}
+		select {
+		case <-waitCtx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
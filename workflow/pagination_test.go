@@ -0,0 +1,20 @@
+package workflow
+
+import "testing"
+
+func TestResultKey(t *testing.T) {
+	html := `<li><div class="feed-shared-update-v2" data-urn="urn:li:activity:7151313167762010113"></div></li>`
+	if key := resultKey(html); key != "urn:li:activity:7151313167762010113" {
+		t.Fatalf("expect urn as key, got %s", key)
+	}
+
+	job := `<li data-occludable-job-id="3912345678"><div>job</div></li>`
+	if key := resultKey(job); key != "3912345678" {
+		t.Fatalf("expect job id as key, got %s", key)
+	}
+
+	withoutUrn := `<li><div>some people result</div></li>`
+	if key := resultKey(withoutUrn); key != withoutUrn {
+		t.Fatalf("expect html as key, got %s", key)
+	}
+}
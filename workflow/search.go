@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	DatePastDay   = "past-24h"
+	DatePastWeek  = "past-week"
+	DatePastMonth = "past-month"
+
+	SortLatest    = "latest"
+	SortRelevance = "relevance"
+
+	ContentJobs      = "jobs"
+	ContentDocuments = "documents"
+	ContentImages    = "images"
+	ContentVideos    = "videos"
+)
+
+var (
+	datePostedValues = map[string]string{
+		DatePastDay:   DatePastDay,
+		DatePastWeek:  DatePastWeek,
+		DatePastMonth: DatePastMonth,
+	}
+	sortByValues = map[string]string{
+		SortLatest:    "date_posted",
+		SortRelevance: "relevance",
+	}
+	contentTypeValues = map[string]string{
+		ContentJobs:      "jobs",
+		ContentDocuments: "documents",
+		ContentImages:    "photos",
+		ContentVideos:    "videos",
+	}
+)
+
+// PostSearch holds the query and filters used in linkedin posts search
+type PostSearch struct {
+	Query       string
+	DatePosted  string
+	SortBy      string
+	ContentType string
+	// FromMember are profile ids (like ACoAAB...) of post authors
+	FromMember []string
+	// AuthorCompany are company ids of post authors
+	AuthorCompany []string
+}
+
+func (s PostSearch) Validate() error {
+	if s.Query == "" {
+		return fmt.Errorf("query is required")
+	}
+	if _, ok := datePostedValues[s.DatePosted]; s.DatePosted != "" && !ok {
+		return fmt.Errorf("invalid date posted, got %v, but only supported are %s, %s and %s",
+			s.DatePosted, DatePastDay, DatePastWeek, DatePastMonth)
+	}
+	if _, ok := sortByValues[s.SortBy]; s.SortBy != "" && !ok {
+		return fmt.Errorf("invalid sort, got %v, but only supported are %s and %s",
+			s.SortBy, SortLatest, SortRelevance)
+	}
+	if _, ok := contentTypeValues[s.ContentType]; s.ContentType != "" && !ok {
+		return fmt.Errorf("invalid content type, got %v, but only supported are %s, %s, %s and %s",
+			s.ContentType, ContentJobs, ContentDocuments, ContentImages, ContentVideos)
+	}
+	return nil
+}
+
+// Url build the search results url, linkedin expect filter values encoded as json
+func (s PostSearch) Url() string {
+	params := url.Values{}
+	params.Set("keywords", s.Query)
+	params.Set("origin", "FACETED_SEARCH")
+	if s.DatePosted != "" {
+		params.Set("datePosted", jsonValue(datePostedValues[s.DatePosted]))
+	}
+	if s.SortBy != "" {
+		params.Set("sortBy", jsonValue(sortByValues[s.SortBy]))
+	}
+	if s.ContentType != "" {
+		params.Set("contentType", jsonValue(contentTypeValues[s.ContentType]))
+	}
+	if len(s.FromMember) > 0 {
+		params.Set("fromMember", jsonValue(s.FromMember))
+	}
+	if len(s.AuthorCompany) > 0 {
+		params.Set("authorCompany", jsonValue(s.AuthorCompany))
+	}
+	return linkedinUrl(searchPath) + "/content/?" + params.Encode()
+}
+
+func keywordsUrl(base, query string) string {
+	params := url.Values{}
+	params.Set("keywords", query)
+	params.Set("origin", "SWITCH_SEARCH_VERTICAL")
+	return base + "?" + params.Encode()
+}
+
+func SearchForPeople(query string) chromedp.Tasks {
+	return chromedp.Tasks{
+		step("SearchForPeople: opening search", StepNavigate, navigate(keywordsUrl(linkedinUrl(searchPath+"/people/"), query))),
+		step("SearchForPeople: waiting for people", StepWait, waitVisible(sel(entity_sel))),
+	}
+}
+
+func SearchForCompanies(query string) chromedp.Tasks {
+	return chromedp.Tasks{
+		step("SearchForCompanies: opening search", StepNavigate, navigate(keywordsUrl(linkedinUrl(searchPath+"/companies/"), query))),
+		step("SearchForCompanies: waiting for companies", StepWait, waitVisible(sel(entity_sel))),
+	}
+}
+
+func SearchForJobs(query string) chromedp.Tasks {
+	return chromedp.Tasks{
+		step("SearchForJobs: opening search", StepNavigate, navigate(keywordsUrl(linkedinUrl(jobsPath), query))),
+		step("SearchForJobs: waiting for jobs", StepWait, waitVisible(sel(job_sel))),
+	}
+}
+
+// LoadEntities collect people or companies from search results, see LoadPosts
+func LoadEntities(ctx context.Context, limit, maxPages int) ([]string, error) {
+	return loadResults(ctx, sel(entity_sel), limit, maxPages, nil)
+}
+
+// LoadJobs collect jobs from search results, see LoadPosts
+func LoadJobs(ctx context.Context, limit, maxPages int) ([]string, error) {
+	return loadResults(ctx, sel(job_sel), limit, maxPages, nil)
+}
+
+func jsonValue(value any) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,74 @@
+package workflow_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/victorfernandesraton/lazydin/workflow"
+)
+
+func TestPostSearchUrl(t *testing.T) {
+	search := workflow.PostSearch{
+		Query:         "golang hiring",
+		DatePosted:    workflow.DatePastWeek,
+		SortBy:        workflow.SortLatest,
+		ContentType:   workflow.ContentImages,
+		FromMember:    []string{"ACoAAB123"},
+		AuthorCompany: []string{"1035"},
+	}
+	if err := search.Validate(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	res, err := url.Parse(search.Url())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if res.Path != "/search/results/content/" {
+		t.Fatalf("unexpected path %s", res.Path)
+	}
+
+	expected := map[string]string{
+		"keywords":      "golang hiring",
+		"datePosted":    `"past-week"`,
+		"sortBy":        `"date_posted"`,
+		"contentType":   `"photos"`,
+		"fromMember":    `["ACoAAB123"]`,
+		"authorCompany": `["1035"]`,
+	}
+	for key, value := range expected {
+		if got := res.Query().Get(key); got != value {
+			t.Fatalf("expect %s=%s, got %s", key, value, got)
+		}
+	}
+}
+
+func TestPostSearchValidate(t *testing.T) {
+	invalid := []workflow.PostSearch{
+		{},
+		{Query: "golang", DatePosted: "yesterday"},
+		{Query: "golang", SortBy: "oldest"},
+		{Query: "golang", ContentType: "polls"},
+	}
+	for _, search := range invalid {
+		if err := search.Validate(); err == nil {
+			t.Fatalf("expected error for %v", search)
+		}
+	}
+
+	if err := (workflow.PostSearch{Query: "golang"}).Validate(); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func TestPostSearchUrlWithBaseUrl(t *testing.T) {
+	workflow.SetBaseUrl("http://127.0.0.1:8080/")
+	defer workflow.SetBaseUrl(workflow.DefaultBaseUrl)
+
+	res, err := url.Parse(workflow.PostSearch{Query: "golang"}.Url())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if res.Host != "127.0.0.1:8080" || res.Path != "/search/results/content/" {
+		t.Fatalf("unexpected url %s", res)
+	}
+}
@@ -0,0 +1,199 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+	"github.com/victorfernandesraton/lazydin/selectors"
+)
+
+// keys of selectors used by workflows, see selectors/selectors.toml
+const (
+	username_sel             = "login.username"
+	password_sel             = "login.password"
+	submit_sel               = "login.submit"
+	search_sel               = "nav.search"
+	post_sel                 = "search.post"
+	entity_sel               = "search.entity"
+	job_sel                  = "search.job"
+	show_more_results_sel    = "search.show_more_results"
+	see_more_sel             = "search.see_more"
+	profileActionButtons_sel = "profile.action_buttons"
+	comment_button_sel       = "comment.button"
+	comment_box_sel          = "comment.box"
+	comment_submit_sel       = "comment.submit"
+	comment_item_sel         = "comment.item"
+	message_box_sel          = "message.box"
+	message_send_sel         = "message.send"
+	message_item_sel         = "message.item"
+	more_connect_sel         = "connect.more_connect"
+	invite_dialog_sel        = "connect.invite_dialog"
+	how_you_know_other_sel   = "connect.how_you_know_other"
+	how_you_know_connect_sel = "connect.how_you_know_connect"
+	invite_add_note_sel      = "connect.add_note"
+	invite_note_sel          = "connect.note"
+	invite_send_sel          = "connect.send"
+	invite_without_note_sel  = "connect.without_note"
+	email_pin_sel            = "challenge.email_pin"
+	authenticator_sel        = "challenge.authenticator"
+	captcha_sel              = "challenge.captcha"
+	pin_submit_sel           = "challenge.pin_submit"
+)
+
+// findTextJS check if elements matching some XPath or CSS selector contains a text
+const findTextJS = `%s.some(selector => {
+	const items = [];
+	if (selector.startsWith("/") || selector.startsWith("(")) {
+		const result = document.evaluate(selector, document, null, XPathResult.ORDERED_NODE_SNAPSHOT_TYPE, null);
+		for (let i = 0; i < result.snapshotLength; i++) items.push(result.snapshotItem(i));
+	} else {
+		items.push(...document.querySelectorAll(selector));
+	}
+	return items.some(item => (item.innerText || item.textContent || "").includes(%s));
+})`
+
+// clickAllJS click every element matching the first XPath or CSS selector with matches, returning how many were clicked
+const clickAllJS = `(() => {
+	for (const selector of %s) {
+		const items = [];
+		if (selector.startsWith("/") || selector.startsWith("(")) {
+			const result = document.evaluate(selector, document, null, XPathResult.ORDERED_NODE_SNAPSHOT_TYPE, null);
+			for (let i = 0; i < result.snapshotLength; i++) items.push(result.snapshotItem(i));
+		} else {
+			items.push(...document.querySelectorAll(selector));
+		}
+		if (items.length > 0) {
+			items.forEach(item => item.click());
+			return items.length;
+		}
+	}
+	return 0;
+})()`
+
+// sel returns the selector registered for key
+func sel(key string) selectors.Selector {
+	return selectors.Get(key)
+}
+
+// match returns the first fallback of selector matching some node now, or empty when none match
+func match(ctx context.Context, selector selectors.Selector) (string, error) {
+	if len(selector.Fallbacks) == 0 {
+		return "", fmt.Errorf("selector %s is not defined", selector.Key)
+	}
+	for _, fallback := range selector.Fallbacks {
+		var nodes []*cdp.Node
+		if err := chromedp.Nodes(fallback, &nodes, chromedp.AtLeast(0)).Do(ctx); err != nil {
+			if ctx.Err() != nil {
+				return "", nil
+			}
+			return "", err
+		}
+		if len(nodes) > 0 {
+			return fallback, nil
+		}
+	}
+	return "", nil
+}
+
+// exists check if some fallback of selector match some node without waiting for it
+func exists(ctx context.Context, selector selectors.Selector) (bool, error) {
+	found, err := match(ctx, selector)
+	return found != "", err
+}
+
+// resolve block until some fallback of selector match a node, returning it
+func resolve(ctx context.Context, selector selectors.Selector) (string, error) {
+	for {
+		found, err := match(ctx, selector)
+		if err != nil || found != "" {
+			return found, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("selector %s not found: %w", selector.Key, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// findNodes wait until some fallback of selector match as a step named name, returning his nodes
+func findNodes(ctx context.Context, name string, selector selectors.Selector) (nodes []*cdp.Node, err error) {
+	err = Run(ctx, step(name, StepWait, chromedp.ActionFunc(func(ctx context.Context) error {
+		found, err := resolve(ctx, selector)
+		if err != nil {
+			return err
+		}
+		return chromedp.Nodes(found, &nodes, chromedp.BySearch).Do(ctx)
+	})))
+	return nodes, err
+}
+
+// waitVisible wait until element matched by selector is visible
+func waitVisible(selector selectors.Selector) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		found, err := resolve(ctx, selector)
+		if err != nil {
+			return err
+		}
+		return chromedp.WaitVisible(found).Do(ctx)
+	}
+}
+
+// waitEnabled wait until element matched by selector is enabled
+func waitEnabled(selector selectors.Selector) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		found, err := resolve(ctx, selector)
+		if err != nil {
+			return err
+		}
+		return chromedp.WaitEnabled(found).Do(ctx)
+	}
+}
+
+// waitNotPresent wait until no fallback of selector match any node
+func waitNotPresent(selector selectors.Selector) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		for {
+			found, err := exists(ctx, selector)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return ctx.Err()
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+}
+
+// query turn a selector into the fallback matching in page, other values are kept as is
+func query(ctx context.Context, value any) (any, error) {
+	selector, ok := value.(selectors.Selector)
+	if !ok {
+		return value, nil
+	}
+	return resolve(ctx, selector)
+}
+
+// clickAll click every element matching selector at once, doing nothing when none match
+func clickAll(selector selectors.Selector) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		if err := pace.pause(ctx, ActionClick); err != nil {
+			return err
+		}
+		encodedSelectors, err := json.Marshal(selector.Fallbacks)
+		if err != nil {
+			return err
+		}
+		var clicked int
+		return chromedp.Evaluate(fmt.Sprintf(clickAllJS, encodedSelectors), &clicked).Do(ctx)
+	}
+}
@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// StepKind is a kind of workflow step, each kind has his own timeout
+type StepKind string
+
+const (
+	StepNavigate StepKind = "navigate"
+	StepWait     StepKind = "wait"
+	StepClick    StepKind = "click"
+	// StepType is never retried, retry could type the text twice, his timeout
+	// only limit finding the field because paced typing of long texts take minutes
+	StepType StepKind = "type"
+	// StepLogin include solving challenges, so it should wait for the user
+	StepLogin StepKind = "login"
+)
+
+// Steps holds timeout of each step kind and how failed steps are retried,
+// steps without timeout run until context is done
+type Steps struct {
+	Timeouts map[StepKind]time.Duration
+	// Retries is how many times a retryable failure is retried
+	Retries int
+	// Backoff is the first pause before retry, doubled after each retry
+	Backoff time.Duration
+}
+
+// DefaultSteps are the step settings used until SetSteps is called
+func DefaultSteps() Steps {
+	return Steps{
+		Timeouts: map[StepKind]time.Duration{
+			StepNavigate: time.Minute,
+			StepWait:     30 * time.Second,
+			StepClick:    30 * time.Second,
+			StepType:     30 * time.Second,
+			StepLogin:    5 * time.Minute,
+		},
+		Retries: 3,
+		Backoff: time.Second,
+	}
+}
+
+var steps = DefaultSteps()
+
+// SetSteps define timeouts and retries used by every workflow step
+func SetSteps(s Steps) {
+	steps = s
+}
+
+// retryableErrors are messages of failures that can work when tried again
+var retryableErrors = []string{
+	"net::ERR_",
+	"page load error",
+	"Node is detached",
+	"Could not find node with given id",
+	"No node with given id found",
+	"Cannot find context with specified id",
+	"Execution context was destroyed",
+	"Inspected target navigated or closed",
+}
+
+// StepError is a failed workflow step
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+func isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	for _, message := range retryableErrors {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+	return false
+}
+
+// step run action with timeout of his kind, retrying retryable failures with
+// exponential backoff, errors are returned with step name
+func step(name string, kind StepKind, action chromedp.Action) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		config := steps
+		backoff := config.Backoff
+		for try := 0; ; try++ {
+			err := runStep(ctx, config.Timeouts[kind], action)
+			if err == nil {
+				return nil
+			}
+			var stepErr *StepError
+			if errors.As(err, &stepErr) {
+				return err
+			}
+			if kind == StepType || try >= config.Retries || !isRetryable(err) {
+				if try > 0 {
+					err = fmt.Errorf("%w (after %d retries)", err, try)
+				}
+				return &StepError{Step: name, Err: err}
+			}
+			if err := sleep(ctx, backoff); err != nil {
+				return &StepError{Step: name, Err: err}
+			}
+			backoff *= 2
+		}
+	}
+}
+
+// stepParentKey keep the context of step without his timeout
+type stepParentKey struct{}
+
+// withoutStepTimeout returns the context of step before his timeout, used by actions
+// that can take longer than any fixed timeout, like typing a long text
+func withoutStepTimeout(ctx context.Context) context.Context {
+	if parent, ok := ctx.Value(stepParentKey{}).(context.Context); ok {
+		return parent
+	}
+	return ctx
+}
+
+func runStep(ctx context.Context, timeout time.Duration, action chromedp.Action) error {
+	if timeout <= 0 {
+		return action.Do(ctx)
+	}
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := action.Do(context.WithValue(stepCtx, stepParentKey{}, withoutStepTimeout(ctx)))
+	if err != nil && ctx.Err() == nil && errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timeout after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	return err
+}
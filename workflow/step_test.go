@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func withSteps(t *testing.T, s Steps) {
+	previous := steps
+	SetSteps(s)
+	t.Cleanup(func() { SetSteps(previous) })
+}
+
+func failing(tries *int, errs ...error) chromedp.ActionFunc {
+	return func(ctx context.Context) error {
+		*tries++
+		if *tries <= len(errs) {
+			return errs[*tries-1]
+		}
+		return nil
+	}
+}
+
+func TestStepRetry(t *testing.T) {
+	withSteps(t, Steps{Retries: 2, Backoff: time.Millisecond})
+	detached := errors.New("Node is detached from document")
+
+	t.Run("retryable failure", func(t *testing.T) {
+		tries := 0
+		if err := step("retry", StepClick, failing(&tries, detached, detached)).Do(context.Background()); err != nil {
+			t.Fatalf(err.Error())
+		}
+		if tries != 3 {
+			t.Fatalf("expect 3 tries, got %d", tries)
+		}
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		tries := 0
+		err := step("SearchForPosts: opening search", StepNavigate, failing(&tries, detached, detached, detached)).Do(context.Background())
+		var stepErr *StepError
+		if !errors.As(err, &stepErr) || stepErr.Step != "SearchForPosts: opening search" {
+			t.Fatalf("expect step error, got %v", err)
+		}
+		if !errors.Is(err, detached) || !strings.Contains(err.Error(), "after 2 retries") {
+			t.Fatalf("expect retries in error, got %s", err.Error())
+		}
+	})
+
+	t.Run("not retryable failure", func(t *testing.T) {
+		tries := 0
+		err := step("not retryable", StepClick, failing(&tries, errors.New("invalid selector"))).Do(context.Background())
+		if err == nil || tries != 1 {
+			t.Fatalf("expect single try, got %d tries", tries)
+		}
+	})
+
+	t.Run("typing is never retried", func(t *testing.T) {
+		tries := 0
+		if err := step("typing", StepType, failing(&tries, detached)).Do(context.Background()); err == nil || tries != 1 {
+			t.Fatalf("expect single try, got %d tries", tries)
+		}
+	})
+
+	t.Run("inner step name is kept", func(t *testing.T) {
+		tries := 0
+		inner := step("inner", StepWait, failing(&tries, errors.New("boom")))
+		err := step("outer", StepLogin, inner).Do(context.Background())
+		var stepErr *StepError
+		if !errors.As(err, &stepErr) || stepErr.Step != "inner" {
+			t.Fatalf("expect inner step error, got %v", err)
+		}
+	})
+}
+
+func TestStepTimeout(t *testing.T) {
+	withSteps(t, Steps{Timeouts: map[StepKind]time.Duration{StepWait: 20 * time.Millisecond}})
+	wait := chromedp.ActionFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	err := step("SearchForPosts: waiting for posts", StepWait, wait).Do(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expect deadline exceeded, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "SearchForPosts: waiting for posts: timeout after 20ms") {
+		t.Fatalf("expect step name and timeout in error, got %s", err.Error())
+	}
+}
+
+func TestStepTypeTimeout(t *testing.T) {
+	withSteps(t, Steps{Timeouts: map[StepKind]time.Duration{StepType: 10 * time.Millisecond, StepLogin: 10 * time.Millisecond}})
+
+	typing := chromedp.ActionFunc(func(ctx context.Context) error {
+		return sleep(withoutStepTimeout(ctx), 30*time.Millisecond)
+	})
+	if err := step("outer", StepLogin, step("typing", StepType, typing)).Do(context.Background()); err != nil {
+		t.Fatalf("expect typing not limited by step timeouts, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := step("typing", StepType, typing).Do(cancelled); err == nil {
+		t.Fatalf("expect typing stopped by cancelled context")
+	}
+}